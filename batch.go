@@ -0,0 +1,146 @@
+package scryfall
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+const defaultBulkBatchSize = 1000
+
+// WithBatchSize sets how many records DownloadBulkDataBatches and
+// ProcessBulkDataBatches pass per call. Defaults to 1000.
+func WithBatchSize(size int) BulkOption {
+	return func(o *bulkOptions) {
+		if size > 0 {
+			o.batchSize = size
+		}
+	}
+}
+
+// WithBatchLatency bounds how long a record may wait in a partial batch. When
+// the stream stalls, the partial batch is flushed once the oldest record has
+// waited this long. Zero, the default, flushes only full batches and the
+// final one.
+func WithBatchLatency(latency time.Duration) BulkOption {
+	return func(o *bulkOptions) {
+		o.batchLatency = max(latency, 0)
+	}
+}
+
+// DownloadBulkDataBatches is like StreamBulkData but passes cards to fn in
+// batches. The final partial batch is flushed when the stream ends, and an
+// error from fn stops the stream and is returned. Calls to fn never overlap,
+// but may happen on a timer goroutine when WithBatchLatency is set.
+func (c *Client) DownloadBulkDataBatches(ctx context.Context, downloadURI string, fn func([]Card) error, opts ...BulkOption) error {
+	return runBatches(ctx, fn, opts, func(add func(Card) error) error {
+		return c.StreamBulkData(ctx, downloadURI, add, opts...)
+	})
+}
+
+// ProcessBulkDataBatches is like ProcessBulkDataStreamContext but passes
+// cards to fn in batches, with the same guarantees as
+// DownloadBulkDataBatches.
+func (c *Client) ProcessBulkDataBatches(ctx context.Context, reader io.Reader, fn func([]Card) error, opts ...BulkOption) error {
+	return runBatches(ctx, fn, opts, func(add func(Card) error) error {
+		return c.ProcessBulkDataStreamContext(ctx, reader, add, opts...)
+	})
+}
+
+// runBatches runs stream with a callback that groups records for fn. The
+// final partial batch is flushed unless fn already failed or ctx was
+// cancelled.
+func runBatches[T any](ctx context.Context, fn func([]T) error, opts []BulkOption, stream func(func(T) error) error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	o := newBulkOptions(opts)
+	b := &batcher[T]{fn: fn, size: o.batchSize, latency: o.batchLatency}
+	b.buf = make([]T, 0, b.size)
+
+	err := stream(b.add)
+	flushErr := b.close(ctx.Err() == nil)
+	if err != nil {
+		return err
+	}
+	return flushErr
+}
+
+// batcher accumulates records and flushes them to fn when a batch fills or,
+// with a latency set, when the oldest record has waited too long.
+type batcher[T any] struct {
+	fn      func([]T) error
+	size    int
+	latency time.Duration
+
+	mu     sync.Mutex
+	buf    []T
+	timer  *time.Timer
+	gen    int
+	err    error
+	closed bool
+}
+
+func (b *batcher[T]) add(v T) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return b.err
+	}
+	b.buf = append(b.buf, v)
+	if len(b.buf) >= b.size {
+		return b.flushLocked()
+	}
+	if len(b.buf) == 1 && b.latency > 0 {
+		gen := b.gen
+		b.timer = time.AfterFunc(b.latency, func() { b.onTimer(gen) })
+	}
+	return nil
+}
+
+func (b *batcher[T]) onTimer(gen int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// A timer from an already flushed batch must not flush its successor.
+	if b.closed || b.err != nil || gen != b.gen {
+		return
+	}
+	_ = b.flushLocked()
+}
+
+func (b *batcher[T]) flushLocked() error {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.gen++
+	if len(b.buf) == 0 {
+		return nil
+	}
+	batch := b.buf
+	b.buf = make([]T, 0, b.size)
+	if err := b.fn(batch); err != nil {
+		b.err = err
+		return err
+	}
+	return nil
+}
+
+// close stops the latency timer and, if flush is set, delivers the final
+// partial batch. It returns the first error from fn.
+func (b *batcher[T]) close(flush bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	if b.err != nil {
+		return b.err
+	}
+	if !flush {
+		if b.timer != nil {
+			b.timer.Stop()
+		}
+		return nil
+	}
+	return b.flushLocked()
+}
@@ -0,0 +1,147 @@
+package scryfall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestProcessBulkDataBatches_SizesAndFinalFlush(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient()
+	var sizes []int
+	var ids []string
+	err := client.ProcessBulkDataBatches(context.Background(), strings.NewReader(bulkPayload(2500)), func(batch []Card) error {
+		sizes = append(sizes, len(batch))
+		for _, card := range batch {
+			ids = append(ids, card.ID)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{1000, 1000, 500}, sizes)
+	require.Len(t, ids, 2500)
+	require.Equal(t, "card-2499", ids[2499])
+}
+
+func TestProcessBulkDataBatches_ErrorStopsStream(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient()
+	boom := errors.New("insert failed")
+	var calls int
+	err := client.ProcessBulkDataBatches(context.Background(), strings.NewReader(bulkPayload(100)), func(batch []Card) error {
+		calls++
+		if calls == 2 {
+			return boom
+		}
+		return nil
+	}, WithBatchSize(10))
+	require.ErrorIs(t, err, boom)
+	require.Equal(t, 2, calls)
+}
+
+func TestProcessBulkDataBatches_LatencyFlush(t *testing.T) {
+	t.Parallel()
+
+	pr, pw := io.Pipe()
+	flushed := make(chan []Card, 10)
+	client := NewClient()
+	done := make(chan error, 1)
+	go func() {
+		done <- client.ProcessBulkDataBatches(context.Background(), pr, func(batch []Card) error {
+			flushed <- batch
+			return nil
+		}, WithBatchSize(100), WithBatchLatency(20*time.Millisecond))
+	}()
+
+	_, err := io.WriteString(pw, `[{"id":"a"},{"id":"b"},`)
+	require.NoError(t, err)
+	select {
+	case batch := <-flushed:
+		require.Len(t, batch, 2)
+	case <-time.After(5 * time.Second):
+		t.Fatal("partial batch was not flushed while the stream stalled")
+	}
+
+	_, err = io.WriteString(pw, `{"id":"c"}]`)
+	require.NoError(t, err)
+	require.NoError(t, pw.Close())
+	require.NoError(t, <-done)
+	batch := <-flushed
+	require.Equal(t, "c", batch[0].ID)
+}
+
+func TestProcessBulkDataBatches_LatencyFlushError(t *testing.T) {
+	t.Parallel()
+
+	pr, pw := io.Pipe()
+	boom := errors.New("boom")
+	var mu sync.Mutex
+	var calls int
+	client := NewClient()
+	done := make(chan error, 1)
+	go func() {
+		done <- client.ProcessBulkDataBatches(context.Background(), pr, func([]Card) error {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			return boom
+		}, WithBatchSize(100), WithBatchLatency(10*time.Millisecond))
+	}()
+
+	_, err := io.WriteString(pw, `[{"id":"a"},`)
+	require.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+	_, _ = io.WriteString(pw, `{"id":"b"}]`)
+	_ = pw.Close()
+
+	require.ErrorIs(t, <-done, boom)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 1, calls)
+}
+
+func TestDownloadBulkDataBatches(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(bulkPayload(25)))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithLimiter(rate.NewLimiter(rate.Inf, 0)))
+	var got []string
+	err := client.DownloadBulkDataBatches(context.Background(), server.URL, func(batch []Card) error {
+		got = append(got, fmt.Sprintf("%s..%s", batch[0].ID, batch[len(batch)-1].ID))
+		return nil
+	}, WithBatchSize(10))
+	require.NoError(t, err)
+	require.Equal(t, []string{"card-0..card-9", "card-10..card-19", "card-20..card-24"}, got)
+}
+
+func TestProcessBulkDataBatches_CancelledSkipsFinalFlush(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := NewClient()
+	var calls int
+	err := client.ProcessBulkDataBatches(ctx, strings.NewReader(bulkPayload(25)), func([]Card) error {
+		calls++
+		cancel()
+		return nil
+	}, WithBatchSize(10))
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 1, calls)
+}
@@ -0,0 +1,222 @@
+package scryfall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 30 * time.Second
+)
+
+// ErrCircuitOpen is matched by errors returned while the circuit breaker is
+// rejecting requests.
+var ErrCircuitOpen = errors.New("scryfall circuit breaker is open")
+
+// CircuitOpenError is returned without contacting Scryfall while the circuit
+// breaker is open.
+type CircuitOpenError struct {
+	// RetryAfter is the time remaining until the breaker admits a probe.
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s (retry after %s)", ErrCircuitOpen, e.RetryAfter.Round(time.Millisecond))
+}
+
+// Is reports whether target is ErrCircuitOpen.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets all requests through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects requests until the open timeout elapses.
+	CircuitOpen
+	// CircuitHalfOpen admits a limited number of probe requests.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// CircuitBreakerConfig configures a CircuitBreaker. Zero values use defaults.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the
+	// circuit. Defaults to 5.
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before probing.
+	// Defaults to 30 seconds.
+	OpenTimeout time.Duration
+	// HalfOpenRequests is the number of concurrent probes admitted while
+	// half-open. Defaults to 1.
+	HalfOpenRequests int
+	// IsFailure classifies a request outcome. By default transport errors,
+	// 429 and 5xx responses count as failures.
+	IsFailure func(status int, err error) bool
+}
+
+// CircuitBreaker stops requests to Scryfall after sustained failures.
+type CircuitBreaker struct {
+	cfg CircuitBreakerConfig
+	now func() time.Time
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probes   int
+	// generation increments on every state change, so outcomes of requests
+	// admitted under an earlier state can be recognised and ignored.
+	generation uint64
+}
+
+// NewCircuitBreaker constructs a breaker in the closed state.
+func NewCircuitBreaker(cfg CircuitBreakerConfig) *CircuitBreaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaultFailureThreshold
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = defaultOpenTimeout
+	}
+	if cfg.HalfOpenRequests <= 0 {
+		cfg.HalfOpenRequests = 1
+	}
+	if cfg.IsFailure == nil {
+		cfg.IsFailure = defaultIsFailure
+	}
+	return &CircuitBreaker{cfg: cfg, now: time.Now}
+}
+
+// WithCircuitBreaker guards all client requests with breaker.
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(c *Client) {
+		if breaker != nil {
+			c.breaker = breaker
+		}
+	}
+}
+
+// CircuitState reports the state of the client's circuit breaker, or
+// CircuitClosed when none is configured.
+func (c *Client) CircuitState() CircuitState {
+	if c.breaker == nil {
+		return CircuitClosed
+	}
+	return c.breaker.State()
+}
+
+// State returns the current breaker state.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advanceLocked()
+	return b.state
+}
+
+// check fails fast while the circuit is open without reserving a probe. It
+// lets callers skip queueing on the rate limiter.
+func (b *CircuitBreaker) check() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advanceLocked()
+	if b.state == CircuitOpen {
+		return b.openErrorLocked()
+	}
+	return nil
+}
+
+// allow admits a request made on behalf of ctx and returns a function that
+// must be called with its outcome.
+func (b *CircuitBreaker) allow(ctx context.Context) (func(status int, err error), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advanceLocked()
+
+	switch b.state {
+	case CircuitOpen:
+		return nil, b.openErrorLocked()
+	case CircuitHalfOpen:
+		if b.probes >= b.cfg.HalfOpenRequests {
+			return nil, &CircuitOpenError{}
+		}
+		b.probes++
+		return b.done(ctx, b.generation, true), nil
+	default:
+		return b.done(ctx, b.generation, false), nil
+	}
+}
+
+// done returns the outcome callback for a request admitted in generation
+// gen. Only the current generation's requests change the state, so while
+// half-open just the probes decide whether the circuit closes.
+//
+// An outcome is ignored when the caller's ctx has ended, since the caller
+// gave up rather than Scryfall failing. Timeouts the request hit on its own,
+// such as http.Client.Timeout or a stalled download, count as failures.
+func (b *CircuitBreaker) done(ctx context.Context, gen uint64, probe bool) func(int, error) {
+	return func(status int, err error) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if gen != b.generation {
+			return
+		}
+		if probe {
+			b.probes--
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if b.cfg.IsFailure(status, err) {
+			b.failures++
+			if b.state == CircuitHalfOpen || b.failures >= b.cfg.FailureThreshold {
+				b.setStateLocked(CircuitOpen)
+				b.openedAt = b.now()
+			}
+			return
+		}
+		b.failures = 0
+		b.setStateLocked(CircuitClosed)
+	}
+}
+
+func (b *CircuitBreaker) advanceLocked() {
+	if b.state == CircuitOpen && b.now().Sub(b.openedAt) >= b.cfg.OpenTimeout {
+		b.setStateLocked(CircuitHalfOpen)
+		b.probes = 0
+	}
+}
+
+func (b *CircuitBreaker) setStateLocked(state CircuitState) {
+	if b.state != state {
+		b.state = state
+		b.generation++
+	}
+}
+
+func (b *CircuitBreaker) openErrorLocked() error {
+	return &CircuitOpenError{RetryAfter: b.cfg.OpenTimeout - b.now().Sub(b.openedAt)}
+}
+
+func defaultIsFailure(status int, err error) bool {
+	return err != nil || status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
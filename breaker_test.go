@@ -0,0 +1,162 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestCircuitBreaker_OpensAndRecovers(t *testing.T) {
+	t.Parallel()
+
+	var hits int32
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(Card{ID: "abc-123"}))
+	}))
+	t.Cleanup(server.Close)
+
+	now := time.Now()
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute})
+	breaker.now = func() time.Time { return now }
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+		WithCircuitBreaker(breaker),
+		WithRequestCoalescing(false),
+	)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetCardByID(ctx, "abc-123")
+		var apiErr *APIError
+		require.True(t, errors.As(err, &apiErr))
+	}
+	require.Equal(t, CircuitOpen, client.CircuitState())
+
+	_, err := client.GetCardByID(ctx, "abc-123")
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	var openErr *CircuitOpenError
+	require.True(t, errors.As(err, &openErr))
+	require.Equal(t, time.Minute, openErr.RetryAfter)
+	require.Equal(t, int32(2), atomic.LoadInt32(&hits))
+
+	// A failed probe re-opens the circuit immediately.
+	now = now.Add(time.Minute)
+	require.Equal(t, CircuitHalfOpen, client.CircuitState())
+	_, err = client.GetCardByID(ctx, "abc-123")
+	require.False(t, errors.Is(err, ErrCircuitOpen))
+	require.Equal(t, CircuitOpen, client.CircuitState())
+
+	now = now.Add(time.Minute)
+	healthy.Store(true)
+	_, err = client.GetCardByID(ctx, "abc-123")
+	require.NoError(t, err)
+	require.Equal(t, CircuitClosed, client.CircuitState())
+	require.Equal(t, int32(4), atomic.LoadInt32(&hits))
+}
+
+func TestCircuitBreaker_LimitsHalfOpenProbes(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Second})
+	breaker.now = func() time.Time { return now }
+
+	done, err := breaker.allow(context.Background())
+	require.NoError(t, err)
+	done(0, errors.New("connection refused"))
+	require.Equal(t, CircuitOpen, breaker.State())
+
+	now = now.Add(time.Second)
+	probe, err := breaker.allow(context.Background())
+	require.NoError(t, err)
+	_, err = breaker.allow(context.Background())
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	probe(http.StatusOK, nil)
+	require.Equal(t, CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreaker_IgnoresStaleOutcomes(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Second})
+	breaker.now = func() time.Time { return now }
+
+	var slow []func(int, error)
+	for i := 0; i < 2; i++ {
+		done, err := breaker.allow(context.Background())
+		require.NoError(t, err)
+		slow = append(slow, done)
+	}
+	failed, err := breaker.allow(context.Background())
+	require.NoError(t, err)
+	failed(0, errors.New("connection refused"))
+	require.Equal(t, CircuitOpen, breaker.State())
+
+	// Requests admitted while closed can neither close an open circuit nor
+	// decide a half-open one before the probe reports back.
+	slow[0](http.StatusOK, nil)
+	require.Equal(t, CircuitOpen, breaker.State())
+
+	now = now.Add(time.Second)
+	probe, err := breaker.allow(context.Background())
+	require.NoError(t, err)
+	slow[1](http.StatusOK, nil)
+	require.Equal(t, CircuitHalfOpen, breaker.State())
+
+	probe(http.StatusServiceUnavailable, nil)
+	require.Equal(t, CircuitOpen, breaker.State())
+}
+
+func TestCircuitBreaker_OpensOnClientTimeouts(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+		WithHTTPClient(&http.Client{Timeout: 20 * time.Millisecond}),
+		WithCircuitBreaker(NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute})),
+		WithRequestCoalescing(false),
+	)
+	for i := 0; i < 2; i++ {
+		_, err := client.GetCardByID(context.Background(), "abc-123")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+	require.Equal(t, CircuitOpen, client.CircuitState())
+}
+
+func TestCircuitBreaker_IgnoresCallerCancellation(t *testing.T) {
+	t.Parallel()
+
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	done, err := breaker.allow(ctx)
+	require.NoError(t, err)
+	cancel()
+	done(0, context.Canceled)
+	require.Equal(t, CircuitClosed, breaker.State())
+}
@@ -0,0 +1,344 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WithDecodeWorkers decodes bulk records on a pool of n goroutines. The
+// callback is still invoked from a single goroutine, so it needs no locking.
+// Values below 2 keep the default sequential decoder.
+func WithDecodeWorkers(n int) BulkOption {
+	return func(o *bulkOptions) {
+		o.decodeWorkers = n
+	}
+}
+
+// WithPreserveOrder makes parallel decoding deliver records to the callback
+// in the order they appear in the stream. Without it records are delivered
+// as soon as they are decoded.
+func WithPreserveOrder() BulkOption {
+	return func(o *bulkOptions) {
+		o.preserveOrder = true
+	}
+}
+
+// BulkCancelledError is returned when the context passed to a bulk stream is
+// cancelled before the stream is fully processed.
+type BulkCancelledError struct {
+	// Cards is the number of records passed to the callback before stopping.
+	Cards int64
+	Err   error
+}
+
+func (e *BulkCancelledError) Error() string {
+	return fmt.Sprintf("bulk processing stopped after %d cards: %v", e.Cards, e.Err)
+}
+
+func (e *BulkCancelledError) Unwrap() error {
+	return e.Err
+}
+
+// ProcessBulkStream parses a bulk data array from reader, decoding each
+// record into a T. Callers that need only a few fields can declare a small
+// struct for T, or combine it with WithFields, to avoid decoding whole cards.
+// Gzip-compressed input is detected and decompressed transparently.
+func ProcessBulkStream[T any](ctx context.Context, reader io.Reader, fn func(T) error, opts ...BulkOption) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return processBulk(ctx, noopSpan{}, reader, fn, newBulkOptions(opts))
+}
+
+// processBulk parses a bulk data array, recording the number of records and
+// the time spent decoding and in the callback as attributes of span.
+// Gzip-compressed input is detected and decompressed transparently.
+func processBulk[T any](ctx context.Context, span Span, reader io.Reader, fn func(T) error, o bulkOptions) (err error) {
+	reader, closeGzip, err := maybeGunzip(reader)
+	if err != nil {
+		return fmt.Errorf("read bulk data: %w", err)
+	}
+	defer func() {
+		_ = closeGzip()
+	}()
+
+	var stats decodeStats
+	defer func() {
+		span.SetAttributes(
+			Attribute{Key: "scryfall.cards", Value: stats.records},
+			Attribute{Key: "scryfall.decode_seconds", Value: stats.decode.Seconds()},
+			Attribute{Key: "scryfall.callback_seconds", Value: stats.callback.Seconds()},
+		)
+	}()
+
+	decode := func(raw []byte, v *T) error {
+		return json.Unmarshal(raw, v)
+	}
+	if len(o.fields) > 0 {
+		decode = func(raw []byte, v *T) error {
+			projected, err := projectFields(raw, o.fields)
+			if err != nil {
+				return err
+			}
+			return json.Unmarshal(projected, v)
+		}
+	}
+	if o.schema != nil {
+		decodeRaw := decode
+		decode = func(raw []byte, v *T) error {
+			o.schema.Observe(raw, v)
+			return decodeRaw(raw, v)
+		}
+	}
+	sc := newRecordScanner(reader)
+	sc.lenient = o.lenient
+	var src recordSource = sc
+	if o.format == BulkFormatNDJSON {
+		src = newLineScanner(reader)
+	}
+	tally := newRecordTally(o)
+	defer func() { tally.report(o.report, stats.records) }()
+	var callbackErr error
+	stats, err = decodeRecords(ctx, src, o, decode, func(v T) error {
+		callbackErr = fn(v)
+		return callbackErr
+	}, tally.bad)
+	if interrupted(ctx, err, callbackErr) {
+		return &BulkCancelledError{Cards: stats.records, Err: ctx.Err()}
+	}
+	return err
+}
+
+// interrupted reports whether err was caused by ctx being cancelled, either
+// directly or by a read failing because of it. Errors returned by the
+// callback and decode failures are the caller's and are never replaced.
+func interrupted(ctx context.Context, err, callbackErr error) bool {
+	ctxErr := ctx.Err()
+	if err == nil || ctxErr == nil {
+		return false
+	}
+	if errors.Is(err, ctxErr) {
+		return true
+	}
+	var rerr *RecordError
+	if callbackErr != nil || errors.As(err, &rerr) || errors.Is(err, ErrTooManyMalformed) {
+		return false
+	}
+	return true
+}
+
+// decodeStats summarises a decodeRecords run for tracing.
+type decodeStats struct {
+	records  int64
+	decode   time.Duration
+	callback time.Duration
+}
+
+type decodedRecord[T any] struct {
+	value  T
+	index  int64
+	offset int64
+	err    error
+}
+
+// decodeRecords splits the array read by sc into records, decodes each into
+// a T and passes it to fn. Decoding runs on o.decodeWorkers goroutines when
+// more than one is configured; the first error stops all of them.
+//
+// bad is called with each record that fails to decode, and with each span
+// the source skipped as malformed; returning nil skips the record. A nil bad
+// stops at the first such record.
+func decodeRecords[T any](ctx context.Context, sc recordSource, o bulkOptions, decode func([]byte, *T) error, fn func(T) error, bad func(*RecordError) error) (decodeStats, error) {
+	if bad == nil {
+		bad = func(rerr *RecordError) error { return rerr }
+	}
+	if o.decodeWorkers < 2 {
+		return decodeSequential(ctx, sc, decode, fn, bad)
+	}
+	return decodeParallel(ctx, sc, o.decodeWorkers, o.preserveOrder, decode, fn, bad)
+}
+
+func decodeSequential[T any](ctx context.Context, sc recordSource, decode func([]byte, *T) error, fn func(T) error, bad func(*RecordError) error) (decodeStats, error) {
+	var stats decodeStats
+	done := ctx.Done()
+	for {
+		select {
+		case <-done:
+			return stats, ctx.Err()
+		default:
+		}
+
+		start := time.Now()
+		raw, index, offset, err := sc.next()
+		if errors.Is(err, io.EOF) {
+			return stats, nil
+		}
+		var rerr *RecordError
+		if errors.As(err, &rerr) {
+			if err := bad(rerr); err != nil {
+				return stats, err
+			}
+			continue
+		}
+		if err != nil {
+			return stats, err
+		}
+		var v T
+		if err := decode(raw, &v); err != nil {
+			if err := bad(&RecordError{Index: index, Offset: offset, Err: err}); err != nil {
+				return stats, err
+			}
+			continue
+		}
+		stats.decode += time.Since(start)
+
+		start = time.Now()
+		err = fn(v)
+		stats.callback += time.Since(start)
+		stats.records++
+		if err != nil {
+			return stats, err
+		}
+	}
+}
+
+func decodeParallel[T any](ctx context.Context, sc recordSource, workers int, ordered bool, decode func([]byte, *T) error, fn func(T) error, bad func(*RecordError) error) (decodeStats, error) {
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type job struct {
+		raw    []byte
+		index  int64
+		offset int64
+		err    error
+		out    chan decodedRecord[T]
+	}
+	// The channel capacities bound how many raw and decoded records can be
+	// in flight, so a slow callback applies backpressure to the reader.
+	jobs := make(chan job, workers)
+	pending := make(chan chan decodedRecord[T], 2*workers)
+	results := make(chan decodedRecord[T], workers)
+
+	var stats decodeStats
+	var decodeNanos atomic.Int64
+	var scanErr error
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+		if ordered {
+			defer close(pending)
+		}
+		for {
+			raw, index, offset, err := sc.next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			j := job{raw: raw, index: index, offset: offset}
+			// Spans skipped by the source travel with the records so the
+			// budget is charged in stream order.
+			var rerr *RecordError
+			if errors.As(err, &rerr) {
+				j = job{index: rerr.Index, offset: rerr.Offset, err: rerr.Err}
+			} else if err != nil {
+				scanErr = err
+				return
+			}
+			if ordered {
+				j.out = make(chan decodedRecord[T], 1)
+				select {
+				case pending <- j.out:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case jobs <- j:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var workerWG sync.WaitGroup
+	for range workers {
+		workerWG.Add(1)
+		go func() {
+			defer workerWG.Done()
+			for j := range jobs {
+				start := time.Now()
+				r := decodedRecord[T]{index: j.index, offset: j.offset, err: j.err}
+				if r.err == nil {
+					r.err = decode(j.raw, &r.value)
+				}
+				decodeNanos.Add(int64(time.Since(start)))
+				if ordered {
+					j.out <- r
+					continue
+				}
+				select {
+				case results <- r:
+				case <-ctx.Done():
+				}
+			}
+		}()
+	}
+	go func() {
+		workerWG.Wait()
+		close(results)
+	}()
+
+	handle := func(r decodedRecord[T]) error {
+		if r.err != nil {
+			return bad(&RecordError{Index: r.index, Offset: r.offset, Err: r.err})
+		}
+		start := time.Now()
+		err := fn(r.value)
+		stats.callback += time.Since(start)
+		stats.records++
+		return err
+	}
+
+	var err error
+	if ordered {
+	consume:
+		for out := range pending {
+			select {
+			case r := <-out:
+				if err = handle(r); err != nil {
+					break consume
+				}
+			case <-ctx.Done():
+				break consume
+			}
+		}
+	} else {
+		for r := range results {
+			if err = handle(r); err != nil {
+				break
+			}
+		}
+	}
+
+	cancel()
+	wg.Wait()
+	workerWG.Wait()
+	stats.decode = time.Duration(decodeNanos.Load())
+
+	if err != nil {
+		return stats, err
+	}
+	if scanErr != nil {
+		return stats, scanErr
+	}
+	return stats, parent.Err()
+}
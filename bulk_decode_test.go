@@ -0,0 +1,193 @@
+package scryfall
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func bulkPayload(n int) string {
+	cards := make([]string, n)
+	for i := range cards {
+		cards[i] = fmt.Sprintf(`{"id":"card-%d","name":"Card %d"}`, i, i)
+	}
+	return "[" + strings.Join(cards, ",") + "]"
+}
+
+func TestProcessBulkDataStream_ParallelPreservesOrder(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient()
+	var ids []string
+	err := client.ProcessBulkDataStream(strings.NewReader(bulkPayload(500)), func(card Card) error {
+		ids = append(ids, card.ID)
+		return nil
+	}, WithDecodeWorkers(8), WithPreserveOrder())
+	require.NoError(t, err)
+	require.Len(t, ids, 500)
+	for i, id := range ids {
+		require.Equal(t, fmt.Sprintf("card-%d", i), id)
+	}
+}
+
+func TestProcessBulkDataStream_ParallelUnordered(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient()
+	seen := make(map[string]bool)
+	err := client.ProcessBulkDataStream(strings.NewReader(bulkPayload(500)), func(card Card) error {
+		seen[card.ID] = true
+		return nil
+	}, WithDecodeWorkers(4))
+	require.NoError(t, err)
+	require.Len(t, seen, 500)
+}
+
+func TestProcessBulkDataStream_ParallelStopsOnCallbackError(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient()
+	boom := errors.New("boom")
+	var calls atomic.Int64
+	err := client.ProcessBulkDataStream(strings.NewReader(bulkPayload(10000)), func(card Card) error {
+		if calls.Add(1) == 10 {
+			return boom
+		}
+		return nil
+	}, WithDecodeWorkers(4), WithPreserveOrder())
+	require.ErrorIs(t, err, boom)
+	require.Equal(t, int64(10), calls.Load())
+}
+
+func TestProcessBulkDataStream_ParallelDecodeError(t *testing.T) {
+	t.Parallel()
+
+	payload := `[{"id":"a"},{"id":12},{"id":"c"}]`
+	client := NewClient()
+	for _, opts := range [][]BulkOption{
+		nil,
+		{WithDecodeWorkers(3), WithPreserveOrder()},
+	} {
+		var ids []string
+		err := client.ProcessBulkDataStream(strings.NewReader(payload), func(card Card) error {
+			ids = append(ids, card.ID)
+			return nil
+		}, opts...)
+		require.ErrorContains(t, err, "decode record 1 at offset 12")
+		require.Equal(t, []string{"a"}, ids)
+	}
+}
+
+func TestProcessBulkDataStream_ParallelBackpressure(t *testing.T) {
+	t.Parallel()
+
+	// A reader that counts how far it has been consumed; with a blocked
+	// callback the pipeline must stop pulling records after a bounded window.
+	payload := []byte(bulkPayload(5000))
+	reader := &countingBytesReader{r: bytes.NewReader(payload)}
+	release := make(chan struct{})
+	done := make(chan error, 1)
+
+	client := NewClient()
+	go func() {
+		done <- client.ProcessBulkDataStream(reader, func(Card) error {
+			<-release
+			return nil
+		}, WithDecodeWorkers(2), WithPreserveOrder())
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	require.Less(t, reader.n.Load(), int64(len(payload)))
+	close(release)
+	require.NoError(t, <-done)
+}
+
+type countingBytesReader struct {
+	r *bytes.Reader
+	n atomic.Int64
+}
+
+func (c *countingBytesReader) Read(p []byte) (int, error) {
+	// Small reads keep the buffered window well below the payload size.
+	n, err := c.r.Read(p[:min(len(p), 512)])
+	c.n.Add(int64(n))
+	return n, err
+}
+
+func TestProcessBulkDataStreamContext_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	for _, opts := range [][]BulkOption{nil, {WithDecodeWorkers(4), WithPreserveOrder()}} {
+		ctx, cancel := context.WithCancel(context.Background())
+		client := NewClient()
+		var calls int64
+		err := client.ProcessBulkDataStreamContext(ctx, strings.NewReader(bulkPayload(1000)), func(Card) error {
+			calls++
+			if calls == 25 {
+				cancel()
+			}
+			return nil
+		}, opts...)
+		require.ErrorIs(t, err, context.Canceled)
+
+		var cancelled *BulkCancelledError
+		require.ErrorAs(t, err, &cancelled)
+		require.Equal(t, calls, cancelled.Cards)
+		require.Less(t, calls, int64(1000))
+	}
+}
+
+func TestStreamBulkData_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	payload := bulkPayload(2000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(payload))
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := NewClient(WithLimiter(rate.NewLimiter(rate.Inf, 0)))
+	var calls int64
+	err := client.DownloadBulkDataStream(ctx, server.URL, func(Card) error {
+		calls++
+		if calls == 3 {
+			cancel()
+		}
+		return nil
+	}, nil)
+
+	var cancelled *BulkCancelledError
+	require.ErrorAs(t, err, &cancelled)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, int64(3), cancelled.Cards)
+}
+
+func TestProcessBulkDataStreamContext_CallbackErrorAfterCancel(t *testing.T) {
+	t.Parallel()
+
+	boom := errors.New("boom")
+	for _, opts := range [][]BulkOption{nil, {WithDecodeWorkers(4), WithPreserveOrder()}} {
+		ctx, cancel := context.WithCancel(context.Background())
+		client := NewClient()
+		err := client.ProcessBulkDataStreamContext(ctx, strings.NewReader(bulkPayload(100)), func(Card) error {
+			cancel()
+			return boom
+		}, opts...)
+		require.ErrorIs(t, err, boom)
+
+		var cancelled *BulkCancelledError
+		require.False(t, errors.As(err, &cancelled))
+	}
+}
@@ -0,0 +1,157 @@
+package scryfall
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+)
+
+// BulkFormat is the record layout of bulk data.
+type BulkFormat string
+
+const (
+	// BulkFormatJSON is a single top-level JSON array, as published by
+	// Scryfall.
+	BulkFormatJSON BulkFormat = "json"
+	// BulkFormatNDJSON is one JSON object per line.
+	BulkFormatNDJSON BulkFormat = "ndjson"
+)
+
+// WithBulkFormat sets the record layout of the input. Bulk streams default
+// to BulkFormatJSON; OpenBulkFile detects the format unless this is given.
+func WithBulkFormat(format BulkFormat) BulkOption {
+	return func(o *bulkOptions) {
+		o.format = format
+	}
+}
+
+// BulkFile is a local bulk data file opened with OpenBulkFile.
+type BulkFile struct {
+	f          *os.File
+	path       string
+	size       int64
+	format     BulkFormat
+	compressed bool
+	opts       []BulkOption
+}
+
+// OpenBulkFile opens a local bulk data file holding a JSON array or
+// newline-delimited JSON, either of which may be gzip-compressed. opts apply
+// to every pass over the file; WithProgress reports bytes read from disk
+// against the file size.
+func OpenBulkFile(path string, opts ...BulkOption) (*BulkFile, error) {
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("open bulk file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("stat bulk file: %w", err)
+	}
+	bf := &BulkFile{f: f, path: path, size: info.Size(), opts: opts}
+	if err := bf.sniff(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	if o := newBulkOptions(opts); o.format != "" {
+		bf.format = o.format
+	}
+	return bf, nil
+}
+
+// sniff detects compression and, from the first significant byte, the
+// record layout.
+func (b *BulkFile) sniff() error {
+	br := bufio.NewReader(b.f)
+	head, err := br.Peek(len(gzipMagic))
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("read bulk file: %w", err)
+	}
+	b.compressed = bytes.Equal(head, gzipMagic)
+
+	reader, closeGzip, err := maybeGunzip(br)
+	if err != nil {
+		return fmt.Errorf("read bulk file: %w", err)
+	}
+	defer func() {
+		_ = closeGzip()
+	}()
+
+	b.format = BulkFormatJSON
+	var one [1]byte
+	for {
+		if _, err := io.ReadFull(reader, one[:]); err != nil {
+			break
+		}
+		if !isSpace(one[0]) {
+			if one[0] == '{' {
+				b.format = BulkFormatNDJSON
+			}
+			break
+		}
+	}
+	if _, err := b.f.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("rewind bulk file: %w", err)
+	}
+	return nil
+}
+
+// Path returns the path the file was opened from.
+func (b *BulkFile) Path() string { return b.path }
+
+// Size returns the size of the file on disk.
+func (b *BulkFile) Size() int64 { return b.size }
+
+// Format returns the detected or configured record layout.
+func (b *BulkFile) Format() BulkFormat { return b.format }
+
+// Compressed reports whether the file is gzip-compressed.
+func (b *BulkFile) Compressed() bool { return b.compressed }
+
+// Close closes the underlying file.
+func (b *BulkFile) Close() error {
+	return b.f.Close()
+}
+
+// Process calls fn for each card in the file, starting from the beginning.
+func (b *BulkFile) Process(ctx context.Context, fn func(Card) error) error {
+	return ProcessBulkFile(ctx, b, fn)
+}
+
+// Cards yields each card in the file, starting from the beginning.
+func (b *BulkFile) Cards(ctx context.Context) iter.Seq2[Card, error] {
+	return seqOf(func(fn func(Card) error) error {
+		return ProcessBulkFile(ctx, b, fn)
+	})
+}
+
+// ProcessBulkFile calls fn for each record in b decoded into a T, starting
+// from the beginning of the file.
+func ProcessBulkFile[T any](ctx context.Context, b *BulkFile, fn func(T) error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, err := b.f.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("rewind bulk file: %w", err)
+	}
+	o := newBulkOptions(b.opts)
+	o.format = b.format
+
+	tracker := newProgressTracker(o.progress, o.progressInterval, PhaseDecoding)
+	tracker.reset(0, b.size)
+	reader := &trackingReader{Reader: b.f, tracker: tracker}
+
+	err := processBulk(ctx, noopSpan{}, reader, func(v T) error {
+		tracker.addCard()
+		return fn(v)
+	}, o)
+	if err == nil {
+		tracker.setPhase(PhaseDone)
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+package scryfall
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeBulkFile(t *testing.T, name string, data []byte, compress bool) string {
+	t.Helper()
+	if compress {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		_, err := gz.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, gz.Close())
+		data = buf.Bytes()
+	}
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestOpenBulkFile_Formats(t *testing.T) {
+	t.Parallel()
+
+	ndjson := "{\"id\":\"card-0\"}\n\n  {\"id\":\"card-1\"}\r\n{\"id\":\"card-2\"}"
+	cases := []struct {
+		name       string
+		data       string
+		compress   bool
+		format     BulkFormat
+		compressed bool
+	}{
+		{"cards.json", bulkPayload(3), false, BulkFormatJSON, false},
+		{"cards.json.gz", bulkPayload(3), true, BulkFormatJSON, true},
+		{"cards.ndjson", ndjson, false, BulkFormatNDJSON, false},
+		{"cards.ndjson.gz", "\n" + ndjson + "\n", true, BulkFormatNDJSON, true},
+	}
+	for _, tc := range cases {
+		path := writeBulkFile(t, tc.name, []byte(tc.data), tc.compress)
+		f, err := OpenBulkFile(path)
+		require.NoError(t, err)
+		require.Equal(t, tc.format, f.Format(), tc.name)
+		require.Equal(t, tc.compressed, f.Compressed(), tc.name)
+
+		// Each pass starts from the beginning of the file.
+		for range 2 {
+			var ids []string
+			require.NoError(t, f.Process(context.Background(), func(card Card) error {
+				ids = append(ids, card.ID)
+				return nil
+			}))
+			require.Equal(t, []string{"card-0", "card-1", "card-2"}, ids, tc.name)
+		}
+		require.NoError(t, f.Close())
+	}
+}
+
+func TestOpenBulkFile_Progress(t *testing.T) {
+	t.Parallel()
+
+	path := writeBulkFile(t, "cards.json.gz", []byte(bulkPayload(200)), true)
+	var last Progress
+	f, err := OpenBulkFile(path, WithProgress(func(p Progress) { last = p }))
+	require.NoError(t, err)
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var count int
+	for _, err := range f.Cards(context.Background()) {
+		require.NoError(t, err)
+		count++
+	}
+	require.Equal(t, 200, count)
+	require.Equal(t, PhaseDone, last.Phase)
+	require.Equal(t, f.Size(), last.Total)
+	require.Equal(t, f.Size(), last.Bytes)
+	require.Equal(t, int64(200), last.Cards)
+}
+
+func TestOpenBulkFile_GenericAndForcedFormat(t *testing.T) {
+	t.Parallel()
+
+	// A lone object sniffs as NDJSON; forcing JSON restores the array check.
+	path := writeBulkFile(t, "one.json", []byte(`{"oracle_id":"o-1","comment":"c"}`), false)
+	f, err := OpenBulkFile(path)
+	require.NoError(t, err)
+	var rulings []Ruling
+	require.NoError(t, ProcessBulkFile(context.Background(), f, func(r Ruling) error {
+		rulings = append(rulings, r)
+		return nil
+	}))
+	require.Equal(t, []Ruling{{OracleID: "o-1", Comment: "c"}}, rulings)
+	require.NoError(t, f.Close())
+
+	f, err = OpenBulkFile(path, WithBulkFormat(BulkFormatJSON))
+	require.NoError(t, err)
+	err = f.Process(context.Background(), func(Card) error { return nil })
+	require.ErrorContains(t, err, "expected '['")
+	require.NoError(t, f.Close())
+
+	_, err = OpenBulkFile(filepath.Join(t.TempDir(), "missing.json"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,277 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	bulkIndexSuffix  = ".idx"
+	bulkIndexVersion = 1
+)
+
+// ErrIndexStale is returned by LoadBulkIndex when the bulk file has changed
+// since the index was built.
+var ErrIndexStale = errors.New("bulk index is stale")
+
+// BulkIndexEntry locates one record in an uncompressed bulk file.
+type BulkIndexEntry struct {
+	ID              string `json:"id"`
+	OracleID        string `json:"oracle_id,omitempty"`
+	Set             string `json:"set,omitempty"`
+	CollectorNumber string `json:"collector_number,omitempty"`
+	Offset          int64  `json:"offset"`
+	Length          int64  `json:"length"`
+}
+
+// BulkIndex maps card identifiers to byte ranges in a bulk file.
+type BulkIndex struct {
+	Version int              `json:"version"`
+	Size    int64            `json:"size"`
+	ModTime time.Time        `json:"mod_time"`
+	Format  BulkFormat       `json:"format"`
+	Entries []BulkIndexEntry `json:"entries"`
+
+	byID        map[string]int
+	byCollector map[string]int
+	byOracle    map[string][]int
+}
+
+// BulkIndexPath returns where the index for the bulk file at path is stored.
+func BulkIndexPath(path string) string {
+	return path + bulkIndexSuffix
+}
+
+// BuildBulkIndex streams the bulk file at path, recording the byte range of
+// every record. The file must be uncompressed so records can be read back
+// with a single seek. Only WithProgress and WithBulkFormat apply.
+func BuildBulkIndex(ctx context.Context, path string, opts ...BulkOption) (*BulkIndex, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	bf, err := OpenBulkFile(path, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = bf.Close()
+	}()
+	if bf.Compressed() {
+		return nil, fmt.Errorf("index %s: compressed bulk files cannot be indexed", path)
+	}
+	info, err := bf.f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat bulk file: %w", err)
+	}
+
+	o := newBulkOptions(opts)
+	tracker := newProgressTracker(o.progress, o.progressInterval, PhaseDecoding)
+	tracker.reset(0, bf.size)
+	reader := &trackingReader{Reader: bf.f, tracker: tracker}
+
+	var src recordSource = newRecordScanner(reader)
+	if bf.Format() == BulkFormatNDJSON {
+		src = newLineScanner(reader)
+	}
+
+	idx := &BulkIndex{
+		Version: bulkIndexVersion,
+		Size:    info.Size(),
+		ModTime: info.ModTime().UTC(),
+		Format:  bf.Format(),
+	}
+	done := ctx.Done()
+	for {
+		select {
+		case <-done:
+			return nil, &BulkCancelledError{Cards: int64(len(idx.Entries)), Err: ctx.Err()}
+		default:
+		}
+		raw, index, offset, err := src.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("index %s: %w", path, err)
+		}
+		entry := BulkIndexEntry{Offset: offset, Length: int64(len(raw))}
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return nil, fmt.Errorf("index %s: %w", path, &RecordError{Index: index, Offset: offset, Err: err})
+		}
+		// The record's own fields must not overwrite its location.
+		entry.Offset, entry.Length = offset, int64(len(raw))
+		idx.Entries = append(idx.Entries, entry)
+		tracker.addCard()
+	}
+	tracker.setPhase(PhaseDone)
+	idx.buildLookups()
+	return idx, nil
+}
+
+// LoadBulkIndex reads the index stored alongside the bulk file at path. It
+// returns ErrIndexStale when the file's size or modification time no longer
+// match the index.
+func LoadBulkIndex(path string) (*BulkIndex, error) {
+	data, err := os.ReadFile(BulkIndexPath(path)) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("read bulk index: %w", err)
+	}
+	idx := &BulkIndex{}
+	if err := json.Unmarshal(data, idx); err != nil {
+		return nil, fmt.Errorf("decode bulk index: %w", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat bulk file: %w", err)
+	}
+	if idx.Version != bulkIndexVersion || idx.Size != info.Size() || !idx.ModTime.Equal(info.ModTime().UTC()) {
+		return nil, ErrIndexStale
+	}
+	idx.buildLookups()
+	return idx, nil
+}
+
+// Save writes the index alongside the bulk file at path, replacing any
+// previous index atomically.
+func (i *BulkIndex) Save(path string) error {
+	target := BulkIndexPath(path)
+	tmp, err := os.CreateTemp(filepath.Dir(target), filepath.Base(target)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write bulk index: %w", err)
+	}
+	if err := json.NewEncoder(tmp).Encode(i); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write bulk index: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write bulk index: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write bulk index: %w", err)
+	}
+	return nil
+}
+
+func (i *BulkIndex) buildLookups() {
+	i.byID = make(map[string]int, len(i.Entries))
+	i.byCollector = make(map[string]int, len(i.Entries))
+	i.byOracle = make(map[string][]int)
+	for n, e := range i.Entries {
+		if e.ID != "" {
+			i.byID[e.ID] = n
+		}
+		if e.Set != "" && e.CollectorNumber != "" {
+			i.byCollector[collectorKey(e.Set, e.CollectorNumber)] = n
+		}
+		if e.OracleID != "" {
+			i.byOracle[e.OracleID] = append(i.byOracle[e.OracleID], n)
+		}
+	}
+}
+
+func collectorKey(set, number string) string {
+	return set + "/" + number
+}
+
+// Lookup returns the entry for a Scryfall card ID.
+func (i *BulkIndex) Lookup(id string) (BulkIndexEntry, bool) {
+	n, ok := i.byID[id]
+	if !ok {
+		return BulkIndexEntry{}, false
+	}
+	return i.Entries[n], true
+}
+
+// LookupCollector returns the entry for a set code and collector number.
+func (i *BulkIndex) LookupCollector(set, number string) (BulkIndexEntry, bool) {
+	n, ok := i.byCollector[collectorKey(set, number)]
+	if !ok {
+		return BulkIndexEntry{}, false
+	}
+	return i.Entries[n], true
+}
+
+// LookupOracle returns the entries for every printing of an Oracle ID.
+func (i *BulkIndex) LookupOracle(oracleID string) []BulkIndexEntry {
+	ns := i.byOracle[oracleID]
+	entries := make([]BulkIndexEntry, len(ns))
+	for k, n := range ns {
+		entries[k] = i.Entries[n]
+	}
+	return entries
+}
+
+// IndexedBulkFile reads individual cards from a bulk file by seeking to
+// their indexed byte ranges. It is safe for concurrent use.
+type IndexedBulkFile struct {
+	f     *os.File
+	index *BulkIndex
+}
+
+// OpenIndexedBulkFile opens the uncompressed bulk file at path, loading its
+// index or, when missing or stale, building and saving a new one.
+func OpenIndexedBulkFile(ctx context.Context, path string, opts ...BulkOption) (*IndexedBulkFile, error) {
+	idx, err := LoadBulkIndex(path)
+	if err != nil {
+		if idx, err = BuildBulkIndex(ctx, path, opts...); err != nil {
+			return nil, err
+		}
+		if err := idx.Save(path); err != nil {
+			return nil, err
+		}
+	}
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("open bulk file: %w", err)
+	}
+	return &IndexedBulkFile{f: f, index: idx}, nil
+}
+
+// Index returns the file's index.
+func (f *IndexedBulkFile) Index() *BulkIndex { return f.index }
+
+// Close closes the underlying file.
+func (f *IndexedBulkFile) Close() error {
+	return f.f.Close()
+}
+
+// Card returns the card with the given Scryfall ID, or ErrCardNotFound.
+func (f *IndexedBulkFile) Card(id string) (*Card, error) {
+	entry, ok := f.index.Lookup(id)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCardNotFound, id)
+	}
+	return ReadIndexed[Card](f, entry)
+}
+
+// CardByCollector returns the card with the given set code and collector
+// number, or ErrCardNotFound.
+func (f *IndexedBulkFile) CardByCollector(set, number string) (*Card, error) {
+	entry, ok := f.index.LookupCollector(set, number)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCardNotFound, collectorKey(set, number))
+	}
+	return ReadIndexed[Card](f, entry)
+}
+
+// ReadIndexed reads and decodes the record at entry into a T.
+func ReadIndexed[T any](f *IndexedBulkFile, entry BulkIndexEntry) (*T, error) {
+	raw := make([]byte, entry.Length)
+	if _, err := f.f.ReadAt(raw, entry.Offset); err != nil {
+		return nil, fmt.Errorf("read indexed record at offset %d: %w", entry.Offset, err)
+	}
+	v := new(T)
+	if err := json.Unmarshal(raw, v); err != nil {
+		return nil, fmt.Errorf("decode indexed record at offset %d: %w", entry.Offset, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,96 @@
+package scryfall
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const indexPayload = `[
+  {"id":"a","oracle_id":"o-1","set":"lea","collector_number":"1","name":"Alpha"},
+  {"id":"b","oracle_id":"o-1","set":"leb","collector_number":"1","name":"Beta \"}\""},
+  {"id":"c","oracle_id":"o-2","set":"lea","collector_number":"2","name":"Gamma"}
+]`
+
+func TestOpenIndexedBulkFile_BuildsAndReuses(t *testing.T) {
+	t.Parallel()
+
+	path := writeBulkFile(t, "cards.json", []byte(indexPayload), false)
+	f, err := OpenIndexedBulkFile(context.Background(), path)
+	require.NoError(t, err)
+
+	card, err := f.Card("b")
+	require.NoError(t, err)
+	require.Equal(t, `Beta "}"`, card.Name)
+
+	card, err = f.CardByCollector("lea", "2")
+	require.NoError(t, err)
+	require.Equal(t, "c", card.ID)
+
+	_, err = f.Card("missing")
+	require.ErrorIs(t, err, ErrCardNotFound)
+
+	printings := f.Index().LookupOracle("o-1")
+	require.Len(t, printings, 2)
+	require.Equal(t, "a", printings[0].ID)
+	require.NoError(t, f.Close())
+
+	_, err = os.Stat(BulkIndexPath(path))
+	require.NoError(t, err)
+	idx, err := LoadBulkIndex(path)
+	require.NoError(t, err)
+	require.Len(t, idx.Entries, 3)
+	entry, ok := idx.Lookup("a")
+	require.True(t, ok)
+	require.Equal(t, byte('{'), indexPayload[entry.Offset])
+	require.Equal(t, byte('}'), indexPayload[entry.Offset+entry.Length-1])
+}
+
+func TestLoadBulkIndex_Stale(t *testing.T) {
+	t.Parallel()
+
+	path := writeBulkFile(t, "cards.json", []byte(indexPayload), false)
+	idx, err := BuildBulkIndex(context.Background(), path)
+	require.NoError(t, err)
+	require.NoError(t, idx.Save(path))
+
+	require.NoError(t, os.WriteFile(path, []byte(`[{"id":"z"}]`), 0o600))
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, later, later))
+	_, err = LoadBulkIndex(path)
+	require.ErrorIs(t, err, ErrIndexStale)
+
+	// Opening rebuilds the stale index.
+	f, err := OpenIndexedBulkFile(context.Background(), path)
+	require.NoError(t, err)
+	defer func() {
+		_ = f.Close()
+	}()
+	card, err := f.Card("z")
+	require.NoError(t, err)
+	require.Equal(t, "z", card.ID)
+}
+
+func TestBuildBulkIndex_NDJSONAndCompressed(t *testing.T) {
+	t.Parallel()
+
+	ndjson := "{\"id\":\"a\",\"name\":\"One\"}\n{\"id\":\"b\",\"name\":\"Two\"}\n"
+	path := writeBulkFile(t, "cards.ndjson", []byte(ndjson), false)
+	f, err := OpenIndexedBulkFile(context.Background(), path)
+	require.NoError(t, err)
+	card, err := f.Card("b")
+	require.NoError(t, err)
+	require.Equal(t, "Two", card.Name)
+	require.NoError(t, f.Close())
+
+	gz := writeBulkFile(t, "cards.json.gz", []byte(indexPayload), true)
+	_, err = BuildBulkIndex(context.Background(), gz)
+	require.ErrorContains(t, err, "compressed")
+
+	_, err = LoadBulkIndex(filepath.Join(t.TempDir(), "none.json"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,100 @@
+package scryfall
+
+import "time"
+
+const defaultDownloadRetries = 2
+
+// BulkOption configures bulk data downloads and processing.
+type BulkOption func(*bulkOptions)
+
+type bulkOptions struct {
+	resume       bool
+	retries      int
+	validateJSON bool
+
+	compressedStorage bool
+
+	progress         ProgressHandler
+	progressFunc     ProgressFunc
+	progressInterval time.Duration
+	expectedSize     int64
+
+	decodeWorkers int
+	preserveOrder bool
+	fields        map[string]bool
+	format        BulkFormat
+
+	lenient   bool
+	maxErrors int
+	report    *BulkReport
+
+	schema *SchemaDetector
+
+	batchSize    int
+	batchLatency time.Duration
+}
+
+func newBulkOptions(opts []BulkOption) bulkOptions {
+	o := bulkOptions{
+		resume:  true,
+		retries: defaultDownloadRetries,
+
+		progressInterval: defaultProgressInterval,
+		batchSize:        defaultBulkBatchSize,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return o
+}
+
+// bulkOptions resolves opts for a bulk stream decoded by c, applying the
+// client's schema detector unless WithSchemaCheck overrides it.
+func (c *Client) bulkOptions(opts []BulkOption) bulkOptions {
+	o := newBulkOptions(opts)
+	if o.schema == nil {
+		o.schema = c.schema
+	}
+	return o
+}
+
+// WithResume controls whether DownloadToFile keeps partial downloads and
+// resumes them with HTTP Range requests. Resuming is enabled by default.
+func WithResume(enabled bool) BulkOption {
+	return func(o *bulkOptions) {
+		o.resume = enabled
+	}
+}
+
+// WithRetries sets how many times a failed download is retried before
+// giving up. Negative values are treated as zero.
+func WithRetries(retries int) BulkOption {
+	return func(o *bulkOptions) {
+		o.retries = max(retries, 0)
+	}
+}
+
+// WithJSONValidation makes DownloadToFile check that the completed file is a
+// single well-formed JSON document before moving it into place.
+func WithJSONValidation() BulkOption {
+	return func(o *bulkOptions) {
+		o.validateJSON = true
+	}
+}
+
+// WithCompressedStorage makes DownloadToFile store the file gzip-compressed,
+// keeping the transfer bytes as-is when the server already sent gzip.
+func WithCompressedStorage() BulkOption {
+	return func(o *bulkOptions) {
+		o.compressedStorage = true
+	}
+}
+
+// withProgressFunc adapts the legacy per-read ProgressFunc callback.
+func withProgressFunc(fn ProgressFunc) BulkOption {
+	return func(o *bulkOptions) {
+		o.progressFunc = fn
+	}
+}
@@ -0,0 +1,321 @@
+package scryfall
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// recordSource yields raw bulk records with their index and byte offset,
+// returning io.EOF after the last one.
+type recordSource interface {
+	next() (raw []byte, index, offset int64, err error)
+}
+
+// recordScanner splits a top-level JSON array into the raw bytes of its
+// elements without decoding them, tracking the byte offset of each element
+// in the underlying stream.
+//
+// When lenient is set, a structural error between elements is returned as a
+// *RecordError covering the skipped bytes, and scanning resumes at the next
+// element or at the end of the array. An empty element, as in ",,", is
+// reported the same way.
+type recordScanner struct {
+	r       *bufio.Reader
+	offset  int64
+	state   scanState
+	index   int64
+	last    int
+	lenient bool
+}
+
+type scanState int
+
+const (
+	scanStart scanState = iota
+	scanFirst
+	scanNext
+	scanDone
+)
+
+func newRecordScanner(r io.Reader) *recordScanner {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReaderSize(r, 64<<10)
+	}
+	return &recordScanner{r: br}
+}
+
+// next returns the next element of the array, its zero-based index and its
+// byte offset. It returns io.EOF after the closing bracket. The returned
+// slice is owned by the caller.
+func (s *recordScanner) next() (raw []byte, index, offset int64, err error) {
+	for {
+		switch s.state {
+		case scanStart:
+			c, err := s.skipSpace()
+			if err != nil {
+				return nil, 0, 0, fmt.Errorf("decode opening bracket: %w", err)
+			}
+			if c != '[' {
+				return nil, 0, 0, fmt.Errorf("expected '[' at start of bulk data")
+			}
+			s.state = scanFirst
+		case scanFirst, scanNext:
+			c, err := s.skipSpace()
+			if err != nil {
+				return nil, 0, 0, fmt.Errorf("decode closing bracket: %w", unexpectedEOF(err))
+			}
+			if c == ']' && s.state == scanFirst {
+				s.state = scanDone
+				continue
+			}
+			if s.state == scanNext {
+				switch c {
+				case ']':
+					s.state = scanDone
+					continue
+				case ',':
+				default:
+					if s.lenient {
+						return nil, 0, 0, s.resync(s.offset - 1)
+					}
+					return nil, 0, 0, fmt.Errorf("expected ',' or ']' after element at offset %d", s.offset-1)
+				}
+				if c, err = s.skipSpace(); err != nil {
+					return nil, 0, 0, fmt.Errorf("decode element: %w", unexpectedEOF(err))
+				}
+			}
+			offset = s.offset - 1
+			if c == ',' && s.lenient {
+				// An empty element: the comma separates the next one, so
+				// keep expecting an element.
+				s.state = scanFirst
+				return nil, 0, 0, s.malformed(offset, errors.New("empty element"))
+			}
+			raw, err = s.readValue(c)
+			if err != nil {
+				return nil, 0, 0, fmt.Errorf("read element at offset %d: %w", offset, err)
+			}
+			index = s.index
+			s.index++
+			s.state = scanNext
+			return raw, index, offset, nil
+		case scanDone:
+			return nil, 0, 0, io.EOF
+		}
+	}
+}
+
+// resync skips from the unexpected byte at start to the next top-level
+// ",{" or "]", leaving the scanner before the next element or after the
+// array. It returns a *RecordError describing the skipped span, or the read
+// error if the stream ends first.
+func (s *recordScanner) resync(start int64) error {
+	// Track nesting from the unexpected byte itself.
+	_ = s.r.UnreadByte()
+	s.offset--
+
+	depth := 0
+	inString, escaped := false, false
+	for {
+		b, err := s.readByte()
+		if err != nil {
+			return fmt.Errorf("resynchronise after offset %d: %w", start, unexpectedEOF(err))
+		}
+		switch {
+		case inString && escaped:
+			escaped = false
+		case inString && b == '\\':
+			escaped = true
+		case inString && b == '"':
+			inString = false
+		case inString:
+		case b == '"':
+			inString = true
+		case b == '{' || b == '[':
+			depth++
+		case (b == '}' || b == ']') && depth > 0:
+			depth--
+		case b == ']':
+			s.state = scanDone
+			return s.malformed(start, skippedBytes(s.offset-1-start))
+		case b == ',' && depth == 0:
+			end := s.offset - 1
+			c, err := s.skipSpace()
+			if err != nil {
+				return fmt.Errorf("resynchronise after offset %d: %w", start, unexpectedEOF(err))
+			}
+			// Leave c to be read again, either as the next element or as
+			// more of the span being skipped.
+			_ = s.r.UnreadByte()
+			s.offset--
+			if c == '{' {
+				s.state = scanFirst
+				return s.malformed(start, skippedBytes(end-start))
+			}
+		}
+	}
+}
+
+// malformed reports the element at offset as unusable, giving it an index so
+// it counts as a record.
+func (s *recordScanner) malformed(offset int64, err error) error {
+	index := s.index
+	s.index++
+	return &RecordError{Index: index, Offset: offset, Err: err}
+}
+
+func skippedBytes(n int64) error {
+	return fmt.Errorf("expected ',' or ']' after element, skipped %d bytes", n)
+}
+
+func (s *recordScanner) readByte() (byte, error) {
+	c, err := s.r.ReadByte()
+	if err == nil {
+		s.offset++
+	}
+	return c, err
+}
+
+func (s *recordScanner) skipSpace() (byte, error) {
+	for {
+		c, err := s.readByte()
+		if err != nil {
+			return 0, err
+		}
+		if !isSpace(c) {
+			return c, nil
+		}
+	}
+}
+
+// readValue reads one JSON value whose first byte, c, has already been
+// consumed. Objects and arrays are matched by depth while honouring string
+// escapes; validation of the contents is left to the decoder.
+func (s *recordScanner) readValue(c byte) ([]byte, error) {
+	raw := make([]byte, 1, max(s.last, 64))
+	raw[0] = c
+	defer func() { s.last = len(raw) }()
+
+	switch c {
+	case '{', '[':
+		depth := 1
+		inString, escaped := false, false
+		for depth > 0 {
+			b, err := s.readByte()
+			if err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			raw = append(raw, b)
+			switch {
+			case inString && escaped:
+				escaped = false
+			case inString && b == '\\':
+				escaped = true
+			case inString && b == '"':
+				inString = false
+			case inString:
+			case b == '"':
+				inString = true
+			case b == '{' || b == '[':
+				depth++
+			case b == '}' || b == ']':
+				depth--
+			}
+		}
+		return raw, nil
+	case '"':
+		escaped := false
+		for {
+			b, err := s.readByte()
+			if err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			raw = append(raw, b)
+			switch {
+			case escaped:
+				escaped = false
+			case b == '\\':
+				escaped = true
+			case b == '"':
+				return raw, nil
+			}
+		}
+	default:
+		for {
+			b, err := s.r.ReadByte()
+			if errors.Is(err, io.EOF) {
+				return raw, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if isSpace(b) || b == ',' || b == ']' {
+				_ = s.r.UnreadByte()
+				return raw, nil
+			}
+			s.offset++
+			raw = append(raw, b)
+		}
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t'
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// lineScanner splits newline-delimited JSON into records, skipping blank
+// lines.
+type lineScanner struct {
+	r      *bufio.Reader
+	offset int64
+	index  int64
+}
+
+func newLineScanner(r io.Reader) *lineScanner {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReaderSize(r, 64<<10)
+	}
+	return &lineScanner{r: br}
+}
+
+func (s *lineScanner) next() (raw []byte, index, offset int64, err error) {
+	for {
+		line, err := s.r.ReadBytes('\n')
+		start := s.offset
+		s.offset += int64(len(line))
+		if len(line) == 0 && err != nil {
+			return nil, 0, 0, err
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, 0, 0, err
+		}
+		lead := 0
+		for lead < len(line) && isSpace(line[lead]) {
+			lead++
+		}
+		end := len(line)
+		for end > lead && isSpace(line[end-1]) {
+			end--
+		}
+		if end == lead {
+			if err != nil {
+				return nil, 0, 0, err
+			}
+			continue
+		}
+		index = s.index
+		s.index++
+		return line[lead:end], index, start + int64(lead), nil
+	}
+}
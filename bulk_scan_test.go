@@ -0,0 +1,96 @@
+package scryfall
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordScanner_SplitsElements(t *testing.T) {
+	t.Parallel()
+
+	input := ` [ {"a":"}{\"]"}, {"b":[1,{"c":2}]} ,"str",42 ]`
+	sc := newRecordScanner(strings.NewReader(input))
+
+	var raws []string
+	var offsets []int64
+	for {
+		raw, index, offset, err := sc.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		require.Equal(t, int64(len(raws)), index)
+		require.Equal(t, string(raw), input[offset:offset+int64(len(raw))])
+		raws = append(raws, string(raw))
+		offsets = append(offsets, offset)
+	}
+	require.Equal(t, []string{`{"a":"}{\"]"}`, `{"b":[1,{"c":2}]}`, `"str"`, `42`}, raws)
+	require.Equal(t, int64(3), offsets[0])
+}
+
+func TestRecordScanner_Errors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"":             "decode opening bracket",
+		`{}`:           "expected '['",
+		`[{"a":1}`:     "decode closing bracket",
+		`[{"a":1} {}]`: "expected ',' or ']'",
+		`[{"a":"1`:     "unexpected EOF",
+	}
+	for input, want := range cases {
+		sc := newRecordScanner(strings.NewReader(input))
+		var err error
+		for err == nil {
+			_, _, _, err = sc.next()
+		}
+		require.ErrorContains(t, err, want, "input %q", input)
+	}
+}
+
+func TestRecordScanner_EmptyArray(t *testing.T) {
+	t.Parallel()
+
+	sc := newRecordScanner(strings.NewReader(" [ ] "))
+	_, _, _, err := sc.next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestRecordScanner_LenientEmptyElements(t *testing.T) {
+	t.Parallel()
+
+	for input, wantOffsets := range map[string][]int64{
+		`[{"id":"a"},, {"id":"b"}]`:  {12},
+		`[,{"id":"a"},,,{"id":"b"}]`: {1, 13, 14},
+	} {
+		sc := newRecordScanner(strings.NewReader(input))
+		sc.lenient = true
+
+		var raws []string
+		var offsets []int64
+		var indexes []int64
+		for {
+			raw, index, _, err := sc.next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			var rerr *RecordError
+			if errors.As(err, &rerr) {
+				require.ErrorContains(t, rerr, "empty element")
+				offsets = append(offsets, rerr.Offset)
+				indexes = append(indexes, rerr.Index)
+				continue
+			}
+			require.NoError(t, err)
+			raws = append(raws, string(raw))
+			indexes = append(indexes, index)
+		}
+		require.Equal(t, []string{`{"id":"a"}`, `{"id":"b"}`}, raws, "input %q", input)
+		require.Equal(t, wantOffsets, offsets, "input %q", input)
+		require.Len(t, indexes, len(raws)+len(offsets))
+	}
+}
@@ -0,0 +1,231 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const bulkManifestName = "manifest.json"
+
+// BulkSyncStatus describes what a sync did for one bulk type.
+type BulkSyncStatus string
+
+const (
+	// BulkSyncDownloaded means a newer export was fetched.
+	BulkSyncDownloaded BulkSyncStatus = "downloaded"
+	// BulkSyncUnchanged means the local copy is already current.
+	BulkSyncUnchanged BulkSyncStatus = "unchanged"
+	// BulkSyncFailed means the type could not be synced; see Err.
+	BulkSyncFailed BulkSyncStatus = "failed"
+)
+
+// BulkManifestEntry records a downloaded bulk export.
+type BulkManifestEntry struct {
+	Type        BulkType  `json:"type"`
+	UpdatedAt   string    `json:"updated_at"`
+	Size        int64     `json:"size"`
+	DownloadURI string    `json:"download_uri"`
+	Path        string    `json:"path"`
+	SyncedAt    time.Time `json:"synced_at"`
+}
+
+// BulkManifest is the local record of downloaded bulk exports, keyed by type.
+type BulkManifest struct {
+	Entries map[BulkType]BulkManifestEntry `json:"entries"`
+}
+
+// BulkSyncResult is the outcome of syncing a single bulk type.
+type BulkSyncResult struct {
+	Type     BulkType
+	Status   BulkSyncStatus
+	Path     string
+	Previous string // UpdatedAt of the local copy before syncing
+	Current  string // UpdatedAt reported by Scryfall
+	Size     int64
+	Err      error
+}
+
+// BulkSyncReport summarises a Sync call.
+type BulkSyncReport struct {
+	Results []BulkSyncResult
+}
+
+// Changed returns the results for types that were downloaded.
+func (r *BulkSyncReport) Changed() []BulkSyncResult {
+	var changed []BulkSyncResult
+	for _, res := range r.Results {
+		if res.Status == BulkSyncDownloaded {
+			changed = append(changed, res)
+		}
+	}
+	return changed
+}
+
+// BulkSyncer keeps a directory of bulk exports up to date, downloading a
+// type only when Scryfall has published a newer version than the one
+// recorded in the directory's manifest.
+type BulkSyncer struct {
+	client *Client
+	dir    string
+	opts   []BulkOption
+
+	mu sync.Mutex
+}
+
+// NewBulkSyncer constructs a syncer storing files and the manifest in dir.
+// opts are passed to DownloadToFile.
+func NewBulkSyncer(client *Client, dir string, opts ...BulkOption) *BulkSyncer {
+	return &BulkSyncer{client: client, dir: dir, opts: opts}
+}
+
+// Manifest returns the manifest currently stored on disk.
+func (s *BulkSyncer) Manifest() (*BulkManifest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.readManifest()
+}
+
+// Sync brings each requested bulk type up to date. Per-type failures are
+// reported in the results and also returned joined as the error; the
+// manifest is updated for every type that succeeded.
+func (s *BulkSyncer) Sync(ctx context.Context, bulkTypes ...BulkType) (*BulkSyncReport, error) {
+	if len(bulkTypes) == 0 {
+		return nil, fmt.Errorf("at least one bulk type is required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create sync directory: %w", err)
+	}
+	manifest, err := s.readManifest()
+	if err != nil {
+		return nil, err
+	}
+	available, err := s.client.ListBulkData(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list bulk data: %w", err)
+	}
+	byType := make(map[BulkType]CardBulkData, len(available))
+	for _, b := range available {
+		byType[BulkType(b.Type)] = b
+	}
+
+	report := &BulkSyncReport{}
+	var errs []error
+	for _, bulkType := range bulkTypes {
+		res := s.syncOne(ctx, manifest, bulkType, byType)
+		if res.Err != nil {
+			errs = append(errs, fmt.Errorf("sync %s: %w", bulkType, res.Err))
+		}
+		report.Results = append(report.Results, res)
+	}
+	return report, errors.Join(errs...)
+}
+
+func (s *BulkSyncer) syncOne(ctx context.Context, manifest *BulkManifest, bulkType BulkType, available map[BulkType]CardBulkData) BulkSyncResult {
+	res := BulkSyncResult{Type: bulkType}
+	prev, hasPrev := manifest.Entries[bulkType]
+	if hasPrev {
+		res.Previous, res.Path, res.Size = prev.UpdatedAt, prev.Path, prev.Size
+	}
+
+	bulk, ok := available[bulkType]
+	if !ok {
+		res.Status, res.Err = BulkSyncFailed, fmt.Errorf("bulk type %q not offered by scryfall", bulkType)
+		return res
+	}
+	res.Current = bulk.UpdatedAt
+
+	if hasPrev && !isNewer(bulk.UpdatedAt, prev.UpdatedAt) && prev.Size == bulk.CompressedSize {
+		if _, err := os.Stat(prev.Path); err == nil {
+			res.Status = BulkSyncUnchanged
+			return res
+		}
+	}
+
+	path := filepath.Join(s.dir, string(bulkType)+".json")
+	if newBulkOptions(s.opts).compressedStorage {
+		path += ".gz"
+	}
+	opts := append([]BulkOption{WithExpectedSize(bulk.CompressedSize)}, s.opts...)
+	if err := s.client.DownloadToFile(ctx, bulk.DownloadURI, path, nil, opts...); err != nil {
+		res.Status, res.Err = BulkSyncFailed, err
+		return res
+	}
+
+	manifest.Entries[bulkType] = BulkManifestEntry{
+		Type:        bulkType,
+		UpdatedAt:   bulk.UpdatedAt,
+		Size:        bulk.CompressedSize,
+		DownloadURI: bulk.DownloadURI,
+		Path:        path,
+		SyncedAt:    time.Now().UTC(),
+	}
+	if err := s.writeManifest(manifest); err != nil {
+		res.Status, res.Err = BulkSyncFailed, err
+		return res
+	}
+	res.Status, res.Path, res.Size = BulkSyncDownloaded, path, bulk.CompressedSize
+	return res
+}
+
+// isNewer reports whether remote is a later timestamp than local, falling
+// back to inequality when either fails to parse.
+func isNewer(remote, local string) bool {
+	r, rerr := time.Parse(time.RFC3339, remote)
+	l, lerr := time.Parse(time.RFC3339, local)
+	if rerr != nil || lerr != nil {
+		return remote != local
+	}
+	return r.After(l)
+}
+
+func (s *BulkSyncer) readManifest() (*BulkManifest, error) {
+	manifest := &BulkManifest{Entries: make(map[BulkType]BulkManifestEntry)}
+	data, err := os.ReadFile(filepath.Join(s.dir, bulkManifestName)) // #nosec G304
+	if errors.Is(err, os.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("decode manifest: %w", err)
+	}
+	if manifest.Entries == nil {
+		manifest.Entries = make(map[BulkType]BulkManifestEntry)
+	}
+	return manifest, nil
+}
+
+func (s *BulkSyncer) writeManifest(manifest *BulkManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode manifest: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.dir, bulkManifestName+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, bulkManifestName)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	return nil
+}
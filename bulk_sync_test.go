@@ -0,0 +1,119 @@
+package scryfall
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestBulkSyncer_SkipsUnchangedExports(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	updated := map[BulkType]string{
+		BulkDefaultCards: "2026-01-01T10:00:00Z",
+		BulkOracleCards:  "2026-01-01T10:00:00Z",
+	}
+	var downloads int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/bulk-data" {
+			mu.Lock()
+			defer mu.Unlock()
+			var data []CardBulkData
+			for bulkType, at := range updated {
+				data = append(data, CardBulkData{
+					Type:        string(bulkType),
+					UpdatedAt:   at,
+					DownloadURI: server.URL + "/files/" + string(bulkType) + ".json",
+				})
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"data": data}))
+			return
+		}
+		atomic.AddInt32(&downloads, 1)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+	)
+	syncer := NewBulkSyncer(client, t.TempDir())
+	ctx := context.Background()
+
+	report, err := syncer.Sync(ctx, BulkDefaultCards, BulkOracleCards)
+	require.NoError(t, err)
+	require.Len(t, report.Changed(), 2)
+	require.Equal(t, int32(2), atomic.LoadInt32(&downloads))
+
+	report, err = syncer.Sync(ctx, BulkDefaultCards, BulkOracleCards)
+	require.NoError(t, err)
+	require.Empty(t, report.Changed())
+	require.Equal(t, int32(2), atomic.LoadInt32(&downloads))
+
+	mu.Lock()
+	updated[BulkDefaultCards] = "2026-01-02T10:00:00Z"
+	mu.Unlock()
+	report, err = syncer.Sync(ctx, BulkDefaultCards, BulkOracleCards, BulkRulings)
+	require.Error(t, err)
+	require.Equal(t, int32(3), atomic.LoadInt32(&downloads))
+
+	statuses := map[BulkType]BulkSyncStatus{}
+	for _, res := range report.Results {
+		statuses[res.Type] = res.Status
+	}
+	require.Equal(t, map[BulkType]BulkSyncStatus{
+		BulkDefaultCards: BulkSyncDownloaded,
+		BulkOracleCards:  BulkSyncUnchanged,
+		BulkRulings:      BulkSyncFailed,
+	}, statuses)
+	require.Equal(t, "2026-01-01T10:00:00Z", report.Results[0].Previous)
+
+	manifest, err := syncer.Manifest()
+	require.NoError(t, err)
+	require.Equal(t, "2026-01-02T10:00:00Z", manifest.Entries[BulkDefaultCards].UpdatedAt)
+}
+
+func TestBulkSyncer_RejectsTruncatedDownload(t *testing.T) {
+	t.Parallel()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/bulk-data" {
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"data": []CardBulkData{{
+				Type:           string(BulkOracleCards),
+				UpdatedAt:      "2026-01-01T10:00:00Z",
+				CompressedSize: 100,
+				DownloadURI:    server.URL + "/files/oracle.json",
+			}}}))
+			return
+		}
+		// Flushing first forces a chunked response with no Content-Length.
+		w.Header().Set("Content-Encoding", "gzip")
+		w.(http.Flusher).Flush()
+		gz := gzip.NewWriter(w)
+		_, _ = gz.Write([]byte(`[]`))
+		_ = gz.Close()
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+	)
+	syncer := NewBulkSyncer(client, t.TempDir(), WithRetries(0))
+
+	report, err := syncer.Sync(context.Background(), BulkOracleCards)
+	require.ErrorIs(t, err, ErrDownloadVerification)
+	require.Equal(t, BulkSyncFailed, report.Results[0].Status)
+}
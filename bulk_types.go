@@ -0,0 +1,109 @@
+package scryfall
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// BulkType identifies a bulk data export published by Scryfall.
+type BulkType string
+
+// Bulk data exports listed by ListBulkData.
+const (
+	BulkOracleCards   BulkType = "oracle_cards"
+	BulkUniqueArtwork BulkType = "unique_artwork"
+	BulkDefaultCards  BulkType = "default_cards"
+	BulkAllCards      BulkType = "all_cards"
+	BulkRulings       BulkType = "rulings"
+)
+
+// CardBulkTypes lists the bulk exports whose records are Card objects.
+var CardBulkTypes = []BulkType{BulkOracleCards, BulkUniqueArtwork, BulkDefaultCards, BulkAllCards}
+
+// IsCards reports whether the export's records are Card objects.
+func (t BulkType) IsCards() bool {
+	switch t {
+	case BulkOracleCards, BulkUniqueArtwork, BulkDefaultCards, BulkAllCards:
+		return true
+	}
+	return false
+}
+
+// StreamBulk downloads and parses a bulk data file whose records decode into
+// a T, such as Ruling for the rulings export. It accepts the same options as
+// StreamBulkData.
+func StreamBulk[T any](ctx context.Context, c *Client, downloadURI string, fn func(T) error, opts ...BulkOption) (err error) {
+	if downloadURI == "" {
+		return fmt.Errorf("download URI is required")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	o := c.bulkOptions(opts)
+
+	op := Operation{Name: OpDownloadBulkDataStream, Route: downloadURI}
+	ctx, span := c.startSpan(ctx, "scryfall."+op.Name, Attribute{Key: "url", Value: downloadURI})
+	defer func() { endSpan(span, err) }()
+	sum := &downloadSummary{attempt: 1}
+	defer c.logDownload(ctx, op, downloadURI, sum)(&err)
+
+	resp, err := c.openDownload(ctx, op, downloadURI, nil)
+	sum.record(resp, err)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	total := resp.ContentLength
+	if total < 0 && o.expectedSize > 0 {
+		total = o.expectedSize
+	}
+	tracker := newProgressTracker(o.progress, o.progressInterval, PhaseDownloading)
+	tracker.reset(0, total)
+
+	var reader io.Reader = &countingReader{Reader: resp.Body, op: op.Name, metrics: c.metrics}
+	reader = &trackingReader{Reader: reader, tracker: tracker}
+	if o.progressFunc != nil {
+		reader = &progressReader{
+			ReadCloser: io.NopCloser(reader),
+			Total:      total,
+			OnRead:     o.progressFunc,
+		}
+	}
+
+	err = processBulk(ctx, span, reader, func(v T) error {
+		tracker.addCard()
+		return fn(v)
+	}, o)
+	if err == nil {
+		tracker.setPhase(PhaseDone)
+	}
+	return err
+}
+
+// StreamCards looks up the latest export of a card bulk type and streams its
+// cards to fn.
+func (c *Client) StreamCards(ctx context.Context, bulkType BulkType, fn func(Card) error, opts ...BulkOption) error {
+	if !bulkType.IsCards() {
+		return fmt.Errorf("bulk type %q does not contain cards", bulkType)
+	}
+	return streamBulkType(ctx, c, bulkType, fn, opts)
+}
+
+// StreamRulings looks up the latest rulings export and streams its rulings
+// to fn.
+func (c *Client) StreamRulings(ctx context.Context, fn func(Ruling) error, opts ...BulkOption) error {
+	return streamBulkType(ctx, c, BulkRulings, fn, opts)
+}
+
+func streamBulkType[T any](ctx context.Context, c *Client, bulkType BulkType, fn func(T) error, opts []BulkOption) error {
+	bulk, err := c.GetBulkDataByType(ctx, bulkType)
+	if err != nil {
+		return fmt.Errorf("get bulk data %s: %w", bulkType, err)
+	}
+	opts = append([]BulkOption{WithExpectedSize(bulk.CompressedSize)}, opts...)
+	return StreamBulk(ctx, c, bulk.DownloadURI, fn, opts...)
+}
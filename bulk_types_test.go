@@ -0,0 +1,66 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestStreamRulings(t *testing.T) {
+	t.Parallel()
+
+	const rulings = `[
+  {"object":"ruling","oracle_id":"o-1","source":"wotc","published_at":"2004-10-04","comment":"First."},
+  {"object":"ruling","oracle_id":"o-2","source":"scryfall","published_at":"2020-01-01","comment":"Second."}
+]`
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bulk-data/rulings":
+			require.NoError(t, json.NewEncoder(w).Encode(CardBulkData{
+				Type:        string(BulkRulings),
+				DownloadURI: server.URL + "/files/rulings.json",
+			}))
+		case "/files/rulings.json":
+			_, _ = w.Write([]byte(rulings))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithBaseURL(server.URL), WithLimiter(rate.NewLimiter(rate.Inf, 0)))
+	var got []Ruling
+	err := client.StreamRulings(context.Background(), func(r Ruling) error {
+		got = append(got, r)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Ruling{
+		{Object: "ruling", OracleID: "o-1", Source: "wotc", PublishedAt: "2004-10-04", Comment: "First."},
+		{Object: "ruling", OracleID: "o-2", Source: "scryfall", PublishedAt: "2020-01-01", Comment: "Second."},
+	}, got)
+}
+
+func TestStreamCards_RejectsNonCardTypes(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithLimiter(rate.NewLimiter(rate.Inf, 0)))
+	err := client.StreamCards(context.Background(), BulkRulings, func(Card) error { return nil })
+	require.ErrorContains(t, err, "does not contain cards")
+}
+
+func TestBulkType_IsCards(t *testing.T) {
+	t.Parallel()
+
+	for _, bt := range CardBulkTypes {
+		require.True(t, bt.IsCards(), bt)
+	}
+	require.False(t, BulkRulings.IsCards())
+	require.False(t, BulkType("unknown").IsCards())
+}
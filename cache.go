@@ -0,0 +1,274 @@
+package scryfall
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheEntry is a cached API response body together with the validators
+// needed to revalidate it against Scryfall.
+type CacheEntry struct {
+	Body         []byte
+	ETag         string
+	LastModified string
+	StoredAt     time.Time
+}
+
+// Cache stores API responses keyed by request URL. Implementations must be
+// safe for concurrent use.
+type Cache interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, entry *CacheEntry)
+	Delete(key string)
+}
+
+// WithCache enables response caching with conditional revalidation.
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		if cache != nil {
+			c.cache = cache
+		}
+	}
+}
+
+const diskCacheSuffix = ".cache"
+
+// DiskCache is a Cache persisted to a directory. Entries are evicted in
+// least-recently-used order once the total size exceeds the configured limit.
+type DiskCache struct {
+	dir      string
+	maxBytes int64
+
+	mu      sync.Mutex
+	entries map[string]*diskCacheItem
+	size    int64
+}
+
+type diskCacheItem struct {
+	size     int64
+	lastUsed time.Time
+}
+
+type diskCacheHeader struct {
+	Key          string    `json:"key"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	StoredAt     time.Time `json:"stored_at"`
+}
+
+// NewDiskCache opens (or creates) a disk cache rooted at dir. A maxBytes
+// value of zero or less disables size-based eviction.
+func NewDiskCache(dir string, maxBytes int64) (*DiskCache, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("cache directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create cache directory: %w", err)
+	}
+	dc := &DiskCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		entries:  make(map[string]*diskCacheItem),
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read cache directory: %w", err)
+	}
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() || !strings.HasSuffix(name, diskCacheSuffix) {
+			continue
+		}
+		info, err := f.Info()
+		if err != nil {
+			continue
+		}
+		dc.entries[strings.TrimSuffix(name, diskCacheSuffix)] = &diskCacheItem{
+			size:     info.Size(),
+			lastUsed: info.ModTime(),
+		}
+		dc.size += info.Size()
+	}
+	dc.mu.Lock()
+	dc.evictLocked()
+	dc.mu.Unlock()
+	return dc, nil
+}
+
+// Get returns the cached entry for key, if present.
+func (d *DiskCache) Get(key string) (*CacheEntry, bool) {
+	name := diskCacheName(key)
+
+	d.mu.Lock()
+	item, ok := d.entries[name]
+	d.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	// Files are replaced by rename, so reading outside the lock sees either
+	// the old or the new entry, never a partial one.
+	entry, err := d.read(name, key)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	// Leave the entry alone if it was replaced or removed while reading.
+	if d.entries[name] != item {
+		if err != nil {
+			return nil, false
+		}
+		return entry, true
+	}
+	if err != nil {
+		d.removeLocked(name)
+		return nil, false
+	}
+	item.lastUsed = time.Now()
+	return entry, true
+}
+
+// Set stores entry under key, evicting older entries if needed.
+func (d *DiskCache) Set(key string, entry *CacheEntry) {
+	if entry == nil {
+		return
+	}
+	name := diskCacheName(key)
+	header, err := json.Marshal(diskCacheHeader{
+		Key:          key,
+		ETag:         entry.ETag,
+		LastModified: entry.LastModified,
+		StoredAt:     entry.StoredAt,
+	})
+	if err != nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	tmp, err := os.CreateTemp(d.dir, name+".tmp-*")
+	if err != nil {
+		return
+	}
+	w := bufio.NewWriter(tmp)
+	_, _ = w.Write(header)
+	_ = w.WriteByte('\n')
+	_, _ = w.Write(entry.Body)
+	if err := w.Flush(); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), d.path(name)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+
+	size := int64(len(header) + 1 + len(entry.Body))
+	if old, ok := d.entries[name]; ok {
+		d.size -= old.size
+	}
+	d.entries[name] = &diskCacheItem{size: size, lastUsed: time.Now()}
+	d.size += size
+	d.evictLocked()
+}
+
+// Delete removes the entry stored under key.
+func (d *DiskCache) Delete(key string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.removeLocked(diskCacheName(key))
+}
+
+// Size reports the total number of bytes currently held on disk.
+func (d *DiskCache) Size() int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.size
+}
+
+func (d *DiskCache) read(name, key string) (*CacheEntry, error) {
+	f, err := os.Open(d.path(name))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	r := bufio.NewReader(f)
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+	var header diskCacheHeader
+	if err := json.Unmarshal(bytes.TrimSpace(line), &header); err != nil {
+		return nil, err
+	}
+	if header.Key != key {
+		return nil, fmt.Errorf("cache key mismatch")
+	}
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return &CacheEntry{
+		Body:         body,
+		ETag:         header.ETag,
+		LastModified: header.LastModified,
+		StoredAt:     header.StoredAt,
+	}, nil
+}
+
+func (d *DiskCache) evictLocked() {
+	if d.maxBytes <= 0 || d.size <= d.maxBytes {
+		return
+	}
+	names := make([]string, 0, len(d.entries))
+	for name := range d.entries {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return d.entries[names[i]].lastUsed.Before(d.entries[names[j]].lastUsed)
+	})
+	for _, name := range names {
+		if d.size <= d.maxBytes {
+			return
+		}
+		d.removeLocked(name)
+	}
+}
+
+func (d *DiskCache) removeLocked(name string) {
+	item, ok := d.entries[name]
+	if !ok {
+		return
+	}
+	_ = os.Remove(d.path(name))
+	d.size -= item.size
+	delete(d.entries, name)
+}
+
+func (d *DiskCache) path(name string) string {
+	return filepath.Join(d.dir, name+diskCacheSuffix)
+}
+
+func diskCacheName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,183 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestDiskCache_RevalidatesWithETag(t *testing.T) {
+	t.Parallel()
+
+	var fullResponses, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(&fullResponses, 1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(Card{ID: "abc-123", Name: "Cached Card"}))
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir, 0)
+	require.NoError(t, err)
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+		WithCache(cache),
+	)
+	for i := 0; i < 2; i++ {
+		card, err := client.GetCardByID(context.Background(), "abc-123")
+		require.NoError(t, err)
+		require.Equal(t, "Cached Card", card.Name)
+	}
+
+	// A fresh cache over the same directory simulates a service restart.
+	reopened, err := NewDiskCache(dir, 0)
+	require.NoError(t, err)
+	client = NewClient(
+		WithBaseURL(server.URL),
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+		WithCache(reopened),
+	)
+	card, err := client.GetCardByID(context.Background(), "abc-123")
+	require.NoError(t, err)
+	require.Equal(t, "Cached Card", card.Name)
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&fullResponses))
+	require.Equal(t, int32(2), atomic.LoadInt32(&notModified))
+}
+
+func TestDiskCache_EvictsUndecodableEntry(t *testing.T) {
+	t.Parallel()
+
+	var fullResponses int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(&fullResponses, 1)
+		w.Header().Set("ETag", `"v1"`)
+		require.NoError(t, json.NewEncoder(w).Encode(Card{ID: "abc-123", Name: "Fresh Card"}))
+	}))
+	t.Cleanup(server.Close)
+
+	cache, err := NewDiskCache(t.TempDir(), 0)
+	require.NoError(t, err)
+	cache.Set(server.URL+"/cards/abc-123", &CacheEntry{Body: []byte(`{"id":`), ETag: `"v1"`, StoredAt: time.Now()})
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+		WithCache(cache),
+	)
+	_, err = client.GetCardByID(context.Background(), "abc-123")
+	require.Error(t, err)
+
+	card, err := client.GetCardByID(context.Background(), "abc-123")
+	require.NoError(t, err)
+	require.Equal(t, "Fresh Card", card.Name)
+	require.Equal(t, int32(1), atomic.LoadInt32(&fullResponses))
+}
+
+func TestDiskCache_SkipsUndecodableResponse(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"id":`))
+	}))
+	t.Cleanup(server.Close)
+
+	cache, err := NewDiskCache(t.TempDir(), 0)
+	require.NoError(t, err)
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+		WithCache(cache),
+	)
+	_, err = client.GetCardByID(context.Background(), "abc-123")
+	require.Error(t, err)
+
+	_, ok := cache.Get(server.URL + "/cards/abc-123")
+	require.False(t, ok)
+	require.Zero(t, cache.Size())
+}
+
+func TestClient_NotModifiedWithoutCacheEntry(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	t.Cleanup(server.Close)
+
+	cache, err := NewDiskCache(t.TempDir(), 0)
+	require.NoError(t, err)
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+		WithCache(cache),
+	)
+	_, err = client.GetCardByID(context.Background(), "abc-123")
+	require.ErrorContains(t, err, "no cached response")
+}
+
+func TestDiskCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	cache, err := NewDiskCache(t.TempDir(), 400)
+	require.NoError(t, err)
+
+	body := make([]byte, 100)
+	cache.Set("a", &CacheEntry{Body: body, ETag: "a", StoredAt: time.Now()})
+	cache.Set("b", &CacheEntry{Body: body, ETag: "b", StoredAt: time.Now()})
+	_, ok := cache.Get("a")
+	require.True(t, ok)
+	cache.Set("c", &CacheEntry{Body: body, ETag: "c", StoredAt: time.Now()})
+
+	_, ok = cache.Get("b")
+	require.False(t, ok)
+	_, ok = cache.Get("a")
+	require.True(t, ok)
+	_, ok = cache.Get("c")
+	require.True(t, ok)
+	require.LessOrEqual(t, cache.Size(), int64(400))
+}
+
+func TestDiskCache_ConcurrentAccess(t *testing.T) {
+	t.Parallel()
+
+	cache, err := NewDiskCache(t.TempDir(), 4096)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("key-%d", i%4)
+			cache.Set(key, &CacheEntry{Body: []byte(key), StoredAt: time.Now()})
+			if entry, ok := cache.Get(key); ok {
+				require.Equal(t, key, string(entry.Body))
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -1,14 +1,14 @@
 package scryfall
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"os"
-	"path/filepath"
+	"sync/atomic"
 	"time"
 
 	"github.com/charmbracelet/log"
@@ -18,7 +18,7 @@ import (
 const (
 	defaultBaseURL           = "https://api.scryfall.com"
 	defaultUserAgent         = "repricah-scryfall/0.1"
-	defaultTimeout           = 15 * time.Second
+	defaultTimeout           = 15 * time.Second // API requests only; see WithDownloadIdleTimeout
 	defaultRequestsPerSecond = 10
 )
 
@@ -29,7 +29,25 @@ type Client struct {
 	baseURL    *url.URL
 	limiter    *rate.Limiter
 	userAgent  string
-	logger     *log.Logger
+	logger     Logger
+	cache      Cache
+
+	middlewares []Middleware
+	metrics     MetricsHook
+	tracer      Tracer
+	breaker     *CircuitBreaker
+
+	priorityAging time.Duration
+	scheduler     *scheduler
+
+	downloadClient      *http.Client
+	downloadIdleTimeout time.Duration
+
+	coalesce  bool
+	flights   flightGroup
+	coalesced atomic.Int64
+
+	schema *SchemaDetector
 }
 
 // Option configures the Scryfall client.
@@ -77,15 +95,26 @@ func WithUserAgent(ua string) Option {
 	}
 }
 
-// WithLogger sets a custom structured logger.
+// WithLogger sets a custom charmbracelet logger. Use WithSlogLogger or
+// WithCustomLogger for other logging libraries.
 func WithLogger(logger *log.Logger) Option {
 	return func(c *Client) {
 		if logger != nil {
-			c.logger = logger
+			c.logger = NewCharmLogger(logger)
 		}
 	}
 }
 
+// WithRequestCoalescing controls whether concurrent identical API requests
+// share a single in-flight HTTP call. Only requests at the same Priority are
+// coalesced, so an interactive lookup never waits behind a background one.
+// Coalescing is enabled by default.
+func WithRequestCoalescing(enabled bool) Option {
+	return func(c *Client) {
+		c.coalesce = enabled
+	}
+}
+
 // NewClient constructs a Scryfall API client with sane defaults.
 func NewClient(opts ...Option) *Client {
 	base, _ := url.Parse(defaultBaseURL)
@@ -94,22 +123,47 @@ func NewClient(opts ...Option) *Client {
 		baseURL:    base,
 		limiter:    rate.NewLimiter(rate.Limit(defaultRequestsPerSecond), defaultRequestsPerSecond),
 		userAgent:  defaultUserAgent,
-		logger:     log.WithPrefix("scryfall"),
+		logger:     NewCharmLogger(log.WithPrefix("scryfall")),
+		coalesce:   true,
+		metrics:    noopMetrics{},
+		tracer:     noopTracer{},
+
+		priorityAging: defaultPriorityAging,
+
+		downloadIdleTimeout: defaultDownloadIdleTimeout,
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.scheduler = newScheduler(c.limiter, c.priorityAging)
+	if c.downloadClient == nil {
+		c.downloadClient = defaultDownloadClient(c.httpClient)
+	}
 	return c
 }
 
+// ClientStats summarises client activity since construction.
+type ClientStats struct {
+	// CoalescedRequests counts calls that were served by joining an
+	// identical request already in flight.
+	CoalescedRequests int64
+}
+
+// Stats returns a snapshot of the client's counters.
+func (c *Client) Stats() ClientStats {
+	return ClientStats{
+		CoalescedRequests: c.coalesced.Load(),
+	}
+}
+
 // GetCardByID retrieves an individual card using its Scryfall UUID.
 func (c *Client) GetCardByID(ctx context.Context, id string) (*Card, error) {
 	if id == "" {
 		return nil, fmt.Errorf("card id is required")
 	}
-	endpoint := fmt.Sprintf("/cards/%s", id)
+	op := Operation{Name: OpGetCardByID, Route: "/cards/{id}", Params: map[string]string{"id": id}}
 	var card Card
-	if err := c.get(ctx, endpoint, &card); err != nil {
+	if err := c.get(ctx, op, &card); err != nil {
 		return nil, err
 	}
 	return &card, nil
@@ -120,18 +174,62 @@ func (c *Client) ListBulkData(ctx context.Context) ([]CardBulkData, error) {
 	var response struct {
 		Data []CardBulkData `json:"data"`
 	}
-	if err := c.get(ctx, "/bulk-data", &response); err != nil {
+	op := Operation{Name: OpListBulkData, Route: "/bulk-data"}
+	if err := c.get(ctx, op, &response); err != nil {
 		return nil, err
 	}
 	return response.Data, nil
 }
 
+// CardIdentifier identifies a card in a collection lookup. Set exactly one
+// identification strategy supported by Scryfall, such as ID or Set plus
+// CollectorNumber.
+type CardIdentifier struct {
+	ID              string `json:"id,omitempty"`
+	OracleID        string `json:"oracle_id,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Set             string `json:"set,omitempty"`
+	CollectorNumber string `json:"collector_number,omitempty"`
+}
+
+// CardCollection is the result of a collection lookup.
+type CardCollection struct {
+	Data     []Card           `json:"data"`
+	NotFound []CardIdentifier `json:"not_found"`
+}
+
+// MaxCollectionIdentifiers is the largest number of identifiers Scryfall
+// accepts in a single collection request.
+const MaxCollectionIdentifiers = 75
+
+// GetCardCollection retrieves up to MaxCollectionIdentifiers cards in a
+// single request. Identifiers Scryfall could not resolve are returned in
+// NotFound.
+func (c *Client) GetCardCollection(ctx context.Context, identifiers []CardIdentifier) (*CardCollection, error) {
+	if len(identifiers) == 0 {
+		return nil, fmt.Errorf("at least one identifier is required")
+	}
+	if len(identifiers) > MaxCollectionIdentifiers {
+		return nil, fmt.Errorf("too many identifiers: %d (max %d)", len(identifiers), MaxCollectionIdentifiers)
+	}
+	payload := struct {
+		Identifiers []CardIdentifier `json:"identifiers"`
+	}{Identifiers: identifiers}
+	var collection CardCollection
+	op := Operation{Name: OpGetCardCollection, Route: "/cards/collection"}
+	if err := c.post(ctx, op, payload, &collection); err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
 // ListSets fetches all sets from Scryfall.
 func (c *Client) ListSets(ctx context.Context) ([]CardSet, error) {
 	var response struct {
 		Data []CardSet `json:"data"`
 	}
-	if err := c.get(ctx, "/sets", &response); err != nil {
+	op := Operation{Name: OpListSets, Route: "/sets"}
+	if err := c.get(ctx, op, &response); err != nil {
 		return nil, err
 	}
 	return response.Data, nil
@@ -141,13 +239,13 @@ func (c *Client) ListSets(ctx context.Context) ([]CardSet, error) {
 type ProgressFunc func(current, total int64)
 
 // GetBulkDataByType retrieves a single bulk data object by its type.
-func (c *Client) GetBulkDataByType(ctx context.Context, bulkType string) (*CardBulkData, error) {
+func (c *Client) GetBulkDataByType(ctx context.Context, bulkType BulkType) (*CardBulkData, error) {
 	if bulkType == "" {
 		return nil, fmt.Errorf("bulk type is required")
 	}
-	path := fmt.Sprintf("/bulk-data/%s", bulkType)
+	op := Operation{Name: OpGetBulkDataByType, Route: "/bulk-data/{type}", Params: map[string]string{"type": string(bulkType)}}
 	var bulkData CardBulkData
-	if err := c.get(ctx, path, &bulkData); err != nil {
+	if err := c.get(ctx, op, &bulkData); err != nil {
 		return nil, err
 	}
 	return &bulkData, nil
@@ -157,131 +255,33 @@ func (c *Client) GetBulkDataByType(ctx context.Context, bulkType string) (*CardB
 // It calls the provided callback for each card object encountered.
 // progressFn, if provided, will be called periodically with the number of bytes read.
 func (c *Client) DownloadBulkDataStream(ctx context.Context, downloadURI string, cardCallback func(Card) error, progressFn ProgressFunc) error {
-	if downloadURI == "" {
-		return fmt.Errorf("download URI is required")
-	}
-	if ctx == nil {
-		ctx = context.Background()
-	}
-
-	c.logger.Info("downloading bulk data (streaming)", "uri", downloadURI)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURI, http.NoBody)
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
-	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", c.userAgent)
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("perform request: %w", err)
-	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("download failed with status %d", resp.StatusCode)
-	}
-
-	var reader io.Reader = resp.Body
-	if progressFn != nil {
-		reader = &progressReader{
-			ReadCloser: resp.Body,
-			Total:      resp.ContentLength,
-			OnRead:     progressFn,
-		}
-	}
-
-	return c.ProcessBulkDataStream(reader, cardCallback)
+	return c.StreamBulkData(ctx, downloadURI, cardCallback, withProgressFunc(progressFn))
 }
 
-// DownloadToFile downloads a bulk data file to a local file path with progress tracking.
-func (c *Client) DownloadToFile(ctx context.Context, downloadURI string, filePath string, progress ProgressFunc) error {
-	if downloadURI == "" {
-		return fmt.Errorf("download URI is required")
-	}
-	if ctx == nil {
-		ctx = context.Background()
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURI, http.NoBody)
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
-	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", c.userAgent)
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("perform request: %w", err)
-	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("download failed with status %d", resp.StatusCode)
-	}
-
-	out, err := os.Create(filepath.Clean(filePath)) // #nosec G304
-	if err != nil {
-		return fmt.Errorf("create file: %w", err)
-	}
-	defer func() {
-		_ = out.Close()
-	}()
-
-	var reader io.Reader = resp.Body
-	if progress != nil {
-		reader = &progressReader{
-			ReadCloser: resp.Body,
-			Total:      resp.ContentLength,
-			OnRead:     progress,
-		}
-	}
-
-	if _, err := io.Copy(out, reader); err != nil {
-		return fmt.Errorf("copy to file: %w", err)
-	}
-
-	return nil
+// StreamBulkData downloads and parses a bulk data file, calling cardCallback
+// for each card. It accepts the same options as DownloadToFile, such as
+// WithProgress for throttled progress events.
+func (c *Client) StreamBulkData(ctx context.Context, downloadURI string, cardCallback func(Card) error, opts ...BulkOption) error {
+	return StreamBulk(ctx, c, downloadURI, cardCallback, opts...)
 }
 
 // ProcessBulkDataStream handles the streaming JSON parsing from an io.Reader.
-func (c *Client) ProcessBulkDataStream(reader io.Reader, cardCallback func(Card) error) error {
-	dec := json.NewDecoder(reader)
-
-	// Read opening bracket
-	t, err := dec.Token()
-	if err != nil {
-		return fmt.Errorf("decode opening bracket: %w", err)
-	}
-	if delim, ok := t.(json.Delim); !ok || delim != '[' {
-		return fmt.Errorf("expected '[' at start of bulk data")
-	}
-
-	for dec.More() {
-		var card Card
-		if err := dec.Decode(&card); err != nil {
-			return fmt.Errorf("decode card object: %w", err)
-		}
-		if err := cardCallback(card); err != nil {
-			return err
-		}
-	}
+// The reader may hold plain JSON or gzip-compressed JSON, such as a .json.gz file.
+// Options such as WithDecodeWorkers control how records are decoded.
+func (c *Client) ProcessBulkDataStream(reader io.Reader, cardCallback func(Card) error, opts ...BulkOption) error {
+	return c.ProcessBulkDataStreamContext(context.Background(), reader, cardCallback, opts...)
+}
 
-	// Read closing bracket
-	t, err = dec.Token()
-	if err != nil {
-		return fmt.Errorf("decode closing bracket: %w", err)
-	}
-	if delim, ok := t.(json.Delim); !ok || delim != ']' {
-		return fmt.Errorf("expected ']' at end of bulk data")
+// ProcessBulkDataStreamContext is like ProcessBulkDataStream but stops between
+// records once ctx is cancelled, returning a *BulkCancelledError that wraps
+// ctx.Err() and records how many cards were processed.
+func (c *Client) ProcessBulkDataStreamContext(ctx context.Context, reader io.Reader, cardCallback func(Card) error, opts ...BulkOption) (err error) {
+	if ctx == nil {
+		ctx = context.Background()
 	}
-
-	return nil
+	ctx, span := c.startSpan(ctx, "scryfall.ProcessBulkDataStream")
+	defer func() { endSpan(span, err) }()
+	return processBulk(ctx, span, reader, cardCallback, c.bulkOptions(opts))
 }
 
 type progressReader struct {
@@ -311,54 +311,200 @@ func (c *Client) DownloadBulkData(ctx context.Context, downloadURI string) ([]Ca
 	return cards, err
 }
 
-func (c *Client) get(ctx context.Context, path string, dest any) error {
+func (c *Client) get(ctx context.Context, op Operation, dest any) error {
+	fullURL, err := c.resolve(op.path())
+	if err != nil {
+		return err
+	}
+	return c.getURL(ctx, op, fullURL, dest)
+}
+
+// getURL is like get but requests fullURL, which may carry a query string
+// or be a next_page link returned by Scryfall.
+func (c *Client) getURL(ctx context.Context, op Operation, fullURL string, dest any) (err error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	if err := c.limiter.Wait(ctx); err != nil {
-		return fmt.Errorf("wait for rate limiter: %w", err)
+	ctx, span := c.startSpan(ctx, "scryfall."+op.Name, Attribute{Key: "route", Value: op.Route})
+	defer func() { endSpan(span, err) }()
+
+	// Only the caller whose fetch ran sees the fresh entry, so a coalesced
+	// response is stored once.
+	var fresh *CacheEntry
+	fetch := func(ctx context.Context) ([]byte, error) {
+		body, entry, err := c.fetch(ctx, op, http.MethodGet, fullURL, nil)
+		fresh = entry
+		return body, err
+	}
+	var body []byte
+	if c.coalesce {
+		var shared bool
+		key := fmt.Sprintf("%d %s", PriorityFromContext(ctx), fullURL)
+		body, shared, err = c.flights.do(ctx, key, fetch)
+		if shared {
+			c.coalesced.Add(1)
+			c.metrics.IncCoalesced(op.Name)
+		}
+	} else {
+		body, err = fetch(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	if err := c.decode(ctx, body, dest); err != nil {
+		// A cached body that no longer decodes would otherwise be
+		// revalidated with 304 and served forever.
+		if c.cache != nil {
+			c.cache.Delete(fullURL)
+		}
+		return err
+	}
+	if fresh != nil {
+		c.cache.Set(fullURL, fresh)
+	}
+	return nil
+}
+
+func (c *Client) post(ctx context.Context, op Operation, payload, dest any) (err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, span := c.startSpan(ctx, "scryfall."+op.Name, Attribute{Key: "route", Value: op.Route})
+	defer func() { endSpan(span, err) }()
+
+	fullURL, err := c.resolve(op.path())
+	if err != nil {
+		return err
+	}
+	reqBody, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode request: %w", err)
+	}
+
+	body, _, err := c.fetch(ctx, op, http.MethodPost, fullURL, reqBody)
+	if err != nil {
+		return err
 	}
+	return c.decode(ctx, body, dest)
+}
 
+func (c *Client) decode(ctx context.Context, body []byte, dest any) (err error) {
+	_, span := c.startSpan(ctx, SpanDecode, Attribute{Key: "scryfall.bytes", Value: len(body)})
+	defer func() { endSpan(span, err) }()
+	c.schema.Observe(body, dest)
+	if err := json.Unmarshal(body, dest); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) resolve(path string) (string, error) {
 	rel, err := url.Parse(path)
 	if err != nil {
-		return fmt.Errorf("invalid path %q: %w", path, err)
+		return "", fmt.Errorf("invalid path %q: %w", path, err)
 	}
-	fullURL := c.baseURL.ResolveReference(rel)
+	return c.baseURL.ResolveReference(rel).String(), nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL.String(), http.NoBody)
+// waitTurn blocks until the scheduler grants ctx's priority a limiter token.
+func (c *Client) waitTurn(ctx context.Context, op Operation) error {
+	_, span := c.startSpan(ctx, SpanLimiterWait)
+	start := time.Now()
+	err := c.scheduler.Wait(ctx, PriorityFromContext(ctx))
+	c.metrics.ObserveLimiterWait(op.Name, time.Since(start))
+	endSpan(span, err)
 	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+		return fmt.Errorf("wait for rate limiter: %w", err)
+	}
+	return nil
+}
+
+// fetch performs a rate-limited API request and returns the raw response
+// body. GET requests consult the response cache when one is configured; a
+// fresh response with validators is returned as an entry for the caller to
+// store once the body is known to decode.
+func (c *Client) fetch(ctx context.Context, op Operation, method, fullURL string, reqBody []byte) ([]byte, *CacheEntry, error) {
+	if c.breaker != nil {
+		if err := c.breaker.check(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := c.waitTurn(ctx, op); err != nil {
+		return nil, nil, err
+	}
+
+	var bodyReader io.Reader = http.NoBody
+	if reqBody != nil {
+		bodyReader = bytes.NewReader(reqBody)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create request: %w", err)
 	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", c.userAgent)
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	cacheable := c.cache != nil && method == http.MethodGet
+	var cached *CacheEntry
+	if cacheable {
+		if entry, ok := c.cache.Get(fullURL); ok {
+			cached = entry
+			if entry.ETag != "" {
+				req.Header.Set("If-None-Match", entry.ETag)
+			}
+			if entry.LastModified != "" {
+				req.Header.Set("If-Modified-Since", entry.LastModified)
+			}
+		}
+	}
 
-	c.logger.Debug("scryfall api request", "method", req.Method, "url", fullURL.String())
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(ctx, c.httpClient, op, req)
 	if err != nil {
-		return fmt.Errorf("perform request: %w", err)
+		return nil, nil, fmt.Errorf("perform request: %w", err)
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
+	if resp.StatusCode == http.StatusNotModified {
+		if cached == nil {
+			return nil, nil, fmt.Errorf("scryfall returned status %d for a request with no cached response", resp.StatusCode)
+		}
+		c.logger.Debug("scryfall cache revalidated", traceFields(ctx, "operation", op.Name, "url", fullURL)...)
+		c.metrics.IncCacheHit(op.Name)
+		return cached.Body, nil, nil
+	}
+
 	if resp.StatusCode >= 400 {
 		apiErr, readErr := decodeAPIError(resp.Body)
 		if readErr != nil {
-			return fmt.Errorf("scryfall error status %d: %w", resp.StatusCode, readErr)
+			return nil, nil, fmt.Errorf("scryfall error status %d: %w", resp.StatusCode, readErr)
 		}
 		apiErr.StatusCode = resp.StatusCode
-		return apiErr
+		return nil, nil, apiErr
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("read response body: %w", err)
-	}
-	if err := json.Unmarshal(body, dest); err != nil {
-		return fmt.Errorf("decode response: %w", err)
+		return nil, nil, fmt.Errorf("read response body: %w", err)
+	}
+	var fresh *CacheEntry
+	if cacheable {
+		etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+		if etag != "" || lastModified != "" {
+			fresh = &CacheEntry{
+				Body:         body,
+				ETag:         etag,
+				LastModified: lastModified,
+				StoredAt:     time.Now(),
+			}
+		}
 	}
-	return nil
+	return body, fresh, nil
 }
 
 // APIError represents an error returned by the Scryfall API.
@@ -0,0 +1,176 @@
+package scryfall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const defaultDownloadIdleTimeout = time.Minute
+
+// ErrDownloadStalled is returned when a bulk download receives no data for
+// longer than the configured idle timeout.
+var ErrDownloadStalled = errors.New("download stalled")
+
+// DownloadError reports an unsuccessful HTTP status from a bulk download.
+type DownloadError struct {
+	StatusCode int
+}
+
+func (e *DownloadError) Error() string {
+	return fmt.Sprintf("download failed with status %d", e.StatusCode)
+}
+
+// WithDownloadHTTPClient sets the HTTP client used for bulk data downloads.
+// Its Timeout should normally be zero; stalls are detected with
+// WithDownloadIdleTimeout instead. When unset, downloads reuse the API
+// client's transport without its total timeout.
+func WithDownloadHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.downloadClient = httpClient
+		}
+	}
+}
+
+// WithDownloadIdleTimeout sets how long a read from a bulk download may wait
+// for data before it is aborted with ErrDownloadStalled. Time spent by the
+// caller between reads, such as in a slow callback, does not count.
+func WithDownloadIdleTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		if timeout > 0 {
+			c.downloadIdleTimeout = timeout
+		}
+	}
+}
+
+// defaultDownloadClient derives a download client from the API client,
+// keeping its transport but dropping the total request deadline.
+func defaultDownloadClient(api *http.Client) *http.Client {
+	return &http.Client{
+		Transport:     api.Transport,
+		CheckRedirect: api.CheckRedirect,
+		Jar:           api.Jar,
+	}
+}
+
+// openDownload issues a rate-limited GET for a bulk data file using the
+// download client. The returned body aborts with ErrDownloadStalled if no
+// data arrives within the idle timeout; callers must close it.
+func (c *Client) openDownload(ctx context.Context, op Operation, downloadURI string, header http.Header) (*http.Response, error) {
+	if c.breaker != nil {
+		if err := c.breaker.check(); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.waitTurn(ctx, op); err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := context.WithCancel(ctx)
+	watchdog := newIdleWatchdog(c.downloadIdleTimeout, cancel)
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, downloadURI, http.NoBody)
+	if err != nil {
+		watchdog.stop()
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	// Requesting gzip explicitly stops net/http from decoding it for us, so
+	// byte counts and ranges refer to the compressed transfer.
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.do(ctx, c.downloadClient, op, req)
+	if err != nil {
+		watchdog.stop()
+		return nil, fmt.Errorf("perform request: %w", watchdog.wrap(err))
+	}
+	if resp.StatusCode >= 400 {
+		_ = resp.Body.Close()
+		watchdog.stop()
+		return nil, &DownloadError{StatusCode: resp.StatusCode}
+	}
+
+	// From here on the watchdog only runs while a Read is waiting for data,
+	// so time the caller spends between reads is never counted as a stall.
+	watchdog.pause()
+	resp.Body = &idleTimeoutBody{ReadCloser: resp.Body, watchdog: watchdog}
+	return resp, nil
+}
+
+// idleWatchdog cancels a request when it stays armed for longer than
+// timeout. It starts armed to cover the wait for response headers.
+type idleWatchdog struct {
+	timeout time.Duration
+	timer   *time.Timer
+	cancel  context.CancelFunc
+
+	mu      sync.Mutex
+	stalled bool
+}
+
+func newIdleWatchdog(timeout time.Duration, cancel context.CancelFunc) *idleWatchdog {
+	w := &idleWatchdog{timeout: timeout, cancel: cancel}
+	w.timer = time.AfterFunc(timeout, func() {
+		w.mu.Lock()
+		w.stalled = true
+		w.mu.Unlock()
+		cancel()
+	})
+	return w
+}
+
+// arm restarts the countdown.
+func (w *idleWatchdog) arm() {
+	w.timer.Reset(w.timeout)
+}
+
+// pause stops the countdown until the next arm.
+func (w *idleWatchdog) pause() {
+	w.timer.Stop()
+}
+
+// stop disarms the watchdog and releases the request context.
+func (w *idleWatchdog) stop() {
+	w.timer.Stop()
+	w.cancel()
+}
+
+// wrap replaces the cancellation error caused by a stall with
+// ErrDownloadStalled.
+func (w *idleWatchdog) wrap(err error) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stalled {
+		return fmt.Errorf("%w: no data for %s", ErrDownloadStalled, w.timeout)
+	}
+	return err
+}
+
+type idleTimeoutBody struct {
+	io.ReadCloser
+	watchdog *idleWatchdog
+}
+
+func (b *idleTimeoutBody) Read(p []byte) (int, error) {
+	b.watchdog.arm()
+	n, err := b.ReadCloser.Read(p)
+	b.watchdog.pause()
+	if err != nil && err != io.EOF {
+		err = b.watchdog.wrap(err)
+	}
+	return n, err
+}
+
+func (b *idleTimeoutBody) Close() error {
+	b.watchdog.stop()
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,451 @@
+package scryfall
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	partialSuffix     = ".part"
+	partialMetaSuffix = ".part.json"
+	retryBackoff      = 500 * time.Millisecond
+)
+
+// partialMeta records what a partial download belongs to so a later attempt
+// can decide whether it is safe to resume.
+type partialMeta struct {
+	URI          string `json:"uri"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	Encoding     string `json:"encoding,omitempty"`
+	Size         int64  `json:"size"`
+}
+
+// validator returns the value to send in If-Range, preferring the ETag.
+func (m partialMeta) validator() string {
+	if m.ETag != "" {
+		return m.ETag
+	}
+	return m.LastModified
+}
+
+// DownloadToFile downloads a bulk data file to a local file path with progress tracking.
+// Data is written to filePath+".part" in the same directory, fsynced,
+// verified against the expected size and atomically renamed into place, so an
+// existing file at filePath is left untouched if the download fails.
+// Gzip transfer encoding is requested and decoded transparently; with
+// WithCompressedStorage the file is instead kept gzip-compressed on disk.
+// Failed downloads are retried, and unless disabled with WithResume(false)
+// the partial file is kept and resumed with an HTTP Range request, both on
+// retry and on a later call for the same URI.
+func (c *Client) DownloadToFile(ctx context.Context, downloadURI string, filePath string, progress ProgressFunc, opts ...BulkOption) (err error) {
+	if downloadURI == "" {
+		return fmt.Errorf("download URI is required")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	o := newBulkOptions(append(opts, withProgressFunc(progress)))
+	filePath = filepath.Clean(filePath)
+	tracker := newProgressTracker(o.progress, o.progressInterval, PhaseDownloading)
+
+	op := Operation{Name: OpDownloadToFile, Route: downloadURI, Params: map[string]string{"file": filePath}}
+	ctx, span := c.startSpan(ctx, "scryfall."+op.Name, Attribute{Key: "url", Value: downloadURI})
+	defer func() { endSpan(span, err) }()
+	sum := &downloadSummary{}
+	defer c.logDownload(ctx, op, downloadURI, sum)(&err)
+
+	for attempt := 1; ; attempt++ {
+		sum.attempt = attempt
+		attemptCtx := context.WithValue(ctx, attemptContextKey{}, attempt)
+		err = c.downloadAttempt(attemptCtx, op, downloadURI, filePath, tracker, sum, o)
+		if err == nil {
+			tracker.setPhase(PhaseDone)
+			return nil
+		}
+		if attempt > o.retries || !retryableDownload(ctx, err) {
+			return err
+		}
+		c.logger.Warn("scryfall download retrying", traceFields(ctx,
+			"operation", op.Name,
+			"url", downloadURI,
+			"attempt", attempt,
+			"error", err,
+		)...)
+		select {
+		case <-time.After(time.Duration(attempt) * retryBackoff):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+func (c *Client) downloadAttempt(ctx context.Context, op Operation, downloadURI, filePath string, tracker *progressTracker, sum *downloadSummary, o bulkOptions) error {
+	partPath := filePath + partialSuffix
+	metaPath := filePath + partialMetaSuffix
+
+	var offset int64
+	var meta partialMeta
+	header := http.Header{}
+	if o.resume {
+		if m, ok := readPartialMeta(metaPath); ok && m.URI == downloadURI {
+			if info, err := os.Stat(partPath); err == nil && info.Size() > 0 {
+				meta, offset = m, info.Size()
+				header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+				if v := m.validator(); v != "" {
+					header.Set("If-Range", v)
+				}
+			}
+		}
+	}
+
+	resp, err := c.openDownload(ctx, op, downloadURI, header)
+	sum.record(resp, err)
+	if err != nil {
+		var dlErr *DownloadError
+		if offset > 0 && errors.As(err, &dlErr) && dlErr.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			if meta.Size == offset {
+				tracker.setPhase(PhaseFinalizing)
+				return finishDownload(partPath, metaPath, filePath, meta.Size, meta.Encoding, o)
+			}
+			removePartial(partPath, metaPath)
+			return c.downloadAttempt(ctx, op, downloadURI, filePath, tracker, sum, o)
+		}
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	total := resp.ContentLength
+	encoding := resp.Header.Get("Content-Encoding")
+	if offset > 0 && resp.StatusCode == http.StatusPartialContent {
+		start, size, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if !ok || start != offset {
+			removePartial(partPath, metaPath)
+			return fmt.Errorf("unexpected content range %q for offset %d", resp.Header.Get("Content-Range"), offset)
+		}
+		flags |= os.O_APPEND
+		total = size
+	} else {
+		// The server ignored the range or the file changed; start over.
+		offset = 0
+		flags |= os.O_TRUNC
+	}
+	// The file is verified against the size the server reported. Without
+	// one, the expected size stands in only for a gzip transfer: it is the
+	// compressed size, while an identity response is stored uncompressed.
+	verifyTotal := total
+	if total < 0 && o.expectedSize > 0 {
+		total = o.expectedSize
+		if strings.EqualFold(encoding, "gzip") {
+			verifyTotal = o.expectedSize
+		}
+	}
+
+	if o.resume {
+		meta = partialMeta{
+			URI:          downloadURI,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Encoding:     encoding,
+			Size:         verifyTotal,
+		}
+		if err := writePartialMeta(metaPath, meta); err != nil {
+			return err
+		}
+	}
+
+	out, err := os.OpenFile(partPath, flags, 0o644) // #nosec G302 G304
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+
+	tracker.setPhase(PhaseDownloading)
+	tracker.reset(offset, total)
+	var reader io.Reader = &countingReader{Reader: resp.Body, op: op.Name, metrics: c.metrics}
+	reader = &trackingReader{Reader: reader, tracker: tracker}
+	if o.progressFunc != nil {
+		reader = &progressReader{
+			ReadCloser: io.NopCloser(reader),
+			Total:      total,
+			Current:    offset,
+			OnRead:     o.progressFunc,
+		}
+	}
+
+	_, copyErr := io.Copy(out, reader)
+	if copyErr == nil {
+		if err := out.Sync(); err != nil {
+			copyErr = fmt.Errorf("sync: %w", err)
+		}
+	}
+	closeErr := out.Close()
+	if copyErr == nil {
+		copyErr = closeErr
+	}
+	if copyErr != nil {
+		if !o.resume {
+			removePartial(partPath, metaPath)
+		}
+		return fmt.Errorf("copy to file: %w", copyErr)
+	}
+
+	tracker.setPhase(PhaseFinalizing)
+	return finishDownload(partPath, metaPath, filePath, verifyTotal, encoding, o)
+}
+
+// finishDownload verifies the completed partial file, converts it to the
+// requested on-disk encoding and renames it over filePath. A file that fails
+// verification is discarded so the next attempt starts from scratch.
+func finishDownload(partPath, metaPath, filePath string, expectedSize int64, encoding string, o bulkOptions) (err error) {
+	if err := verifySize(partPath, expectedSize); err != nil {
+		removePartial(partPath, metaPath)
+		return err
+	}
+
+	finalPath := partPath
+	gzipped := strings.EqualFold(encoding, "gzip")
+	if gzipped != o.compressedStorage {
+		converted, recodeErr := recodeFile(partPath, gzipped, o.compressedStorage)
+		if recodeErr != nil {
+			removePartial(partPath, metaPath)
+			return recodeErr
+		}
+		finalPath = converted
+		defer func() {
+			if err != nil {
+				_ = os.Remove(converted)
+			}
+		}()
+	}
+
+	if o.validateJSON {
+		if err := validateJSONFile(finalPath); err != nil {
+			removePartial(partPath, metaPath)
+			return err
+		}
+	}
+	if err := os.Rename(finalPath, filePath); err != nil {
+		return fmt.Errorf("move download into place: %w", err)
+	}
+	removePartial(partPath, metaPath)
+	return syncDir(filepath.Dir(filePath))
+}
+
+// recodeFile writes a sibling temp file holding src decompressed or
+// compressed as requested, and returns its path.
+func recodeFile(src string, gzipped, compress bool) (_ string, err error) {
+	in, err := os.Open(src) // #nosec G304
+	if err != nil {
+		return "", fmt.Errorf("open download: %w", err)
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	out, err := os.CreateTemp(filepath.Dir(src), filepath.Base(src)+".*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("create temp file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = out.Close()
+			_ = os.Remove(out.Name())
+		}
+	}()
+
+	switch {
+	case gzipped && !compress:
+		gz, err := gzip.NewReader(bufio.NewReader(in))
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrDownloadVerification, err)
+		}
+		if _, err := io.Copy(out, gz); err != nil {
+			return "", fmt.Errorf("%w: decompress: %w", ErrDownloadVerification, err)
+		}
+	case !gzipped && compress:
+		gz := gzip.NewWriter(out)
+		if _, err := io.Copy(gz, in); err != nil {
+			return "", fmt.Errorf("compress download: %w", err)
+		}
+		if err := gz.Close(); err != nil {
+			return "", fmt.Errorf("compress download: %w", err)
+		}
+	}
+
+	if err := out.Sync(); err != nil {
+		return "", fmt.Errorf("sync: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return "", fmt.Errorf("close temp file: %w", err)
+	}
+	return out.Name(), nil
+}
+
+// ErrDownloadVerification is matched by errors returned when a completed
+// download fails its size or JSON check.
+var ErrDownloadVerification = errors.New("download verification failed")
+
+func verifySize(path string, expectedSize int64) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("stat download: %w", err)
+	}
+	if expectedSize >= 0 && info.Size() != expectedSize {
+		return fmt.Errorf("%w: got %d bytes, expected %d", ErrDownloadVerification, info.Size(), expectedSize)
+	}
+	return nil
+}
+
+// validateJSONFile checks the JSON structure of path, decompressing it first
+// if it is gzipped.
+func validateJSONFile(path string) error {
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return fmt.Errorf("open download: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	r, closeFn, err := maybeGunzip(f)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrDownloadVerification, err)
+	}
+	defer func() {
+		_ = closeFn()
+	}()
+	if err := checkJSONStructure(r); err != nil {
+		return fmt.Errorf("%w: %w", ErrDownloadVerification, err)
+	}
+	return nil
+}
+
+// checkJSONStructure tokenizes r in constant memory, confirming it holds a
+// single well-formed JSON value.
+func checkJSONStructure(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	depth := 0
+	seen := false
+	for {
+		t, err := dec.Token()
+		if err == io.EOF {
+			if depth != 0 || !seen {
+				return io.ErrUnexpectedEOF
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		seen = true
+		if delim, ok := t.(json.Delim); ok {
+			switch delim {
+			case '[', '{':
+				depth++
+			default:
+				depth--
+			}
+		}
+		if depth == 0 && dec.More() {
+			return fmt.Errorf("unexpected data after top-level value")
+		}
+	}
+}
+
+// syncDir flushes a directory entry so a rename survives a crash.
+func syncDir(dir string) error {
+	d, err := os.Open(dir) // #nosec G304
+	if err != nil {
+		return fmt.Errorf("open directory: %w", err)
+	}
+	defer func() {
+		_ = d.Close()
+	}()
+	if err := d.Sync(); err != nil && !errors.Is(err, os.ErrInvalid) {
+		return fmt.Errorf("sync directory: %w", err)
+	}
+	return nil
+}
+
+func removePartial(partPath, metaPath string) {
+	_ = os.Remove(partPath)
+	_ = os.Remove(metaPath)
+}
+
+func readPartialMeta(path string) (partialMeta, bool) {
+	data, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return partialMeta{}, false
+	}
+	var meta partialMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return partialMeta{}, false
+	}
+	return meta, true
+}
+
+func writePartialMeta(path string, meta partialMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("encode download metadata: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write download metadata: %w", err)
+	}
+	return nil
+}
+
+// parseContentRange parses a "bytes start-end/size" header. size is -1 when
+// the server reports it as unknown.
+func parseContentRange(value string) (start, size int64, ok bool) {
+	rest, found := strings.CutPrefix(value, "bytes ")
+	if !found {
+		return 0, 0, false
+	}
+	span, total, found := strings.Cut(rest, "/")
+	if !found {
+		return 0, 0, false
+	}
+	first, _, found := strings.Cut(span, "-")
+	if !found {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if total == "*" {
+		return start, -1, true
+	}
+	size, err = strconv.ParseInt(total, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, size, true
+}
+
+// retryableDownload reports whether err is worth another attempt: the
+// caller's context is still live and the failure was not a client error.
+func retryableDownload(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var dlErr *DownloadError
+	if errors.As(err, &dlErr) {
+		return dlErr.StatusCode == http.StatusTooManyRequests || dlErr.StatusCode >= http.StatusInternalServerError
+	}
+	return !errors.Is(err, ErrCircuitOpen)
+}
@@ -0,0 +1,189 @@
+package scryfall
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+var resumePayload = bytes.Repeat([]byte(`{"id":"card"},`), 1000)
+
+// flakyRangeServer serves resumePayload with Range support, aborting the
+// first failures responses halfway through.
+func flakyRangeServer(t *testing.T, failures int32, ranges *[]string) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*ranges = append(*ranges, r.Header.Get("Range"))
+		mu.Unlock()
+		if atomic.AddInt32(&count, 1) <= failures {
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Content-Length", strconv.Itoa(len(resumePayload)))
+			_, _ = w.Write(resumePayload[:len(resumePayload)/2])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeContent(w, r, "bulk.json", time.Time{}, bytes.NewReader(resumePayload))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDownloadToFile_ResumesOnRetry(t *testing.T) {
+	t.Parallel()
+
+	var ranges []string
+	server := flakyRangeServer(t, 1, &ranges)
+	client := NewClient(WithLimiter(rate.NewLimiter(rate.Inf, 0)))
+
+	path := filepath.Join(t.TempDir(), "bulk.json")
+	var last int64
+	err := client.DownloadToFile(context.Background(), server.URL, path, func(current, total int64) {
+		last = current
+		require.Equal(t, int64(len(resumePayload)), total)
+	})
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, resumePayload, got)
+	require.Equal(t, int64(len(resumePayload)), last)
+	require.Equal(t, []string{"", "bytes=" + strconv.Itoa(len(resumePayload)/2) + "-"}, ranges)
+	require.NoFileExists(t, path+partialSuffix)
+	require.NoFileExists(t, path+partialMetaSuffix)
+}
+
+func TestDownloadToFile_ResumesOnLaterRun(t *testing.T) {
+	t.Parallel()
+
+	var ranges []string
+	server := flakyRangeServer(t, 1, &ranges)
+	client := NewClient(WithLimiter(rate.NewLimiter(rate.Inf, 0)))
+	path := filepath.Join(t.TempDir(), "bulk.json")
+
+	err := client.DownloadToFile(context.Background(), server.URL, path, nil, WithRetries(0))
+	require.Error(t, err)
+	require.FileExists(t, path+partialSuffix)
+	require.NoFileExists(t, path)
+
+	require.NoError(t, client.DownloadToFile(context.Background(), server.URL, path, nil))
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, resumePayload, got)
+	require.Len(t, ranges, 2)
+	require.NotEmpty(t, ranges[1])
+}
+
+func TestDownloadToFile_FallsBackWhenRangeIgnored(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_, _ = w.Write(resumePayload)
+	}))
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "bulk.json")
+	require.NoError(t, os.WriteFile(path+partialSuffix, []byte("stale partial data"), 0o600))
+	require.NoError(t, writePartialMeta(path+partialMetaSuffix, partialMeta{URI: server.URL, Size: -1}))
+
+	client := NewClient(WithLimiter(rate.NewLimiter(rate.Inf, 0)))
+	require.NoError(t, client.DownloadToFile(context.Background(), server.URL, path, nil))
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, resumePayload, got)
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestDownloadToFile_KeepsExistingFileOnFailure(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"id":"card-1"},`))
+	}))
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "bulk.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"id":"old"}]`), 0o600))
+
+	client := NewClient(WithLimiter(rate.NewLimiter(rate.Inf, 0)))
+	err := client.DownloadToFile(context.Background(), server.URL, path, nil, WithJSONValidation(), WithRetries(0))
+	require.ErrorIs(t, err, ErrDownloadVerification)
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, `[{"id":"old"}]`, string(got))
+	require.NoFileExists(t, path+partialSuffix)
+}
+
+func TestDownloadToFile_RemovesRecodedFileWhenRenameFails(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"id":"card-1"}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	// A non-empty directory at the target path makes the final rename fail.
+	path := filepath.Join(dir, "bulk.json.gz")
+	require.NoError(t, os.MkdirAll(filepath.Join(path, "occupied"), 0o750))
+
+	client := NewClient(WithLimiter(rate.NewLimiter(rate.Inf, 0)))
+	err := client.DownloadToFile(context.Background(), server.URL, path, nil, WithCompressedStorage(), WithRetries(0))
+	require.ErrorContains(t, err, "move download into place")
+
+	tmps, err := filepath.Glob(filepath.Join(dir, "*.tmp"))
+	require.NoError(t, err)
+	require.Empty(t, tmps)
+}
+
+func TestDownloadToFile_ExpectedSizeOnlyVerifiesGzip(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A chunked identity response is stored at its uncompressed size.
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte(`[{"id":"a"}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "bulk.json")
+	client := NewClient(WithLimiter(rate.NewLimiter(rate.Inf, 0)))
+	err := client.DownloadToFile(context.Background(), server.URL, path, nil, WithExpectedSize(5), WithRetries(0))
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, `[{"id":"a"}]`, string(got))
+}
+
+func TestCheckJSONStructure(t *testing.T) {
+	t.Parallel()
+
+	for input, valid := range map[string]bool{
+		`[{"id":"a"},{"id":"b"}]`: true,
+		`[{"id":"a"},`:            false,
+		`[{"id":"a"}] trailing`:   false,
+		``:                        false,
+	} {
+		err := checkJSONStructure(bytes.NewReader([]byte(input)))
+		require.Equal(t, valid, err == nil, input)
+	}
+}
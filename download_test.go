@@ -0,0 +1,92 @@
+package scryfall
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+// slowBulkServer streams a two-card array, pausing between chunks.
+func slowBulkServer(t *testing.T, pause time.Duration) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "test-agent", r.Header.Get("User-Agent"))
+		flusher := w.(http.Flusher)
+		for _, chunk := range []string{`[{"id":"card-1"}`, `,`, `{"id":"card-2"}`, `]`} {
+			_, _ = w.Write([]byte(chunk))
+			flusher.Flush()
+			select {
+			case <-time.After(pause):
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDownloadBulkDataStream_IgnoresAPITimeout(t *testing.T) {
+	t.Parallel()
+
+	server := slowBulkServer(t, 40*time.Millisecond)
+	client := NewClient(
+		WithHTTPClient(&http.Client{Timeout: 50 * time.Millisecond}),
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+		WithUserAgent("test-agent"),
+		WithDownloadIdleTimeout(time.Second),
+	)
+
+	var seen []string
+	err := client.DownloadBulkDataStream(context.Background(), server.URL, func(card Card) error {
+		seen = append(seen, card.ID)
+		return nil
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"card-1", "card-2"}, seen)
+}
+
+func TestDownloadBulkDataStream_DetectsStall(t *testing.T) {
+	t.Parallel()
+
+	server := slowBulkServer(t, time.Second)
+	client := NewClient(
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+		WithUserAgent("test-agent"),
+		WithDownloadIdleTimeout(50*time.Millisecond),
+	)
+
+	err := client.DownloadBulkDataStream(context.Background(), server.URL, func(Card) error {
+		return nil
+	}, nil)
+	require.ErrorIs(t, err, ErrDownloadStalled)
+}
+
+func TestDownloadBulkDataStream_SlowCallbackIsNotAStall(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(bulkPayload(500)))
+	}))
+	t.Cleanup(server.Close)
+	client := NewClient(
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+		WithDownloadIdleTimeout(50*time.Millisecond),
+	)
+
+	var cards int
+	err := client.DownloadBulkDataStream(context.Background(), server.URL, func(Card) error {
+		cards++
+		if cards == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+		return nil
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 500, cards)
+}
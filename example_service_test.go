@@ -39,7 +39,7 @@ func (f fakeClient) ListSets(ctx context.Context) ([]scryfall.CardSet, error) {
 	return nil, nil
 }
 
-func (f fakeClient) GetBulkDataByType(ctx context.Context, bulkType string) (*scryfall.CardBulkData, error) {
+func (f fakeClient) GetBulkDataByType(ctx context.Context, bulkType scryfall.BulkType) (*scryfall.CardBulkData, error) {
 	return nil, nil
 }
 
@@ -0,0 +1,147 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestGetCardByID_CoalescesConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	var hits int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(Card{ID: "abc-123", Name: "Trending"}))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+	)
+
+	const callers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			card, err := client.GetCardByID(context.Background(), "abc-123")
+			if err == nil && card.Name != "Trending" {
+				err = &APIError{Details: "unexpected card " + card.Name}
+			}
+			errs <- err
+		}()
+	}
+
+	require.Eventually(t, func() bool {
+		client.flights.mu.Lock()
+		defer client.flights.mu.Unlock()
+		for _, call := range client.flights.calls {
+			return call.waiters == callers
+		}
+		return false
+	}, time.Second, 5*time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&hits))
+	require.Equal(t, int64(callers-1), client.Stats().CoalescedRequests)
+}
+
+func TestGetCardByID_CoalescesOnlySamePriority(t *testing.T) {
+	t.Parallel()
+
+	var hits int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			<-release
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(Card{ID: "abc-123"}))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+	)
+
+	background := make(chan error, 1)
+	go func() {
+		_, err := client.GetCardByID(ContextWithPriority(context.Background(), PriorityBackground), "abc-123")
+		background <- err
+	}()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&hits) == 1 }, time.Second, 5*time.Millisecond)
+
+	// The interactive lookup makes its own call instead of joining the
+	// background one, which is still blocked.
+	_, err := client.GetCardByID(ContextWithPriority(context.Background(), PriorityInteractive), "abc-123")
+	require.NoError(t, err)
+	require.Equal(t, int64(0), client.Stats().CoalescedRequests)
+
+	close(release)
+	require.NoError(t, <-background)
+	require.Equal(t, int32(2), atomic.LoadInt32(&hits))
+}
+
+func TestFlightGroup_CancelledWaiterDoesNotFailOthers(t *testing.T) {
+	t.Parallel()
+
+	var g flightGroup
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fn := func(ctx context.Context) ([]byte, error) {
+		close(started)
+		select {
+		case <-release:
+			return []byte("ok"), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, _, err := g.do(leaderCtx, "key", fn)
+		leaderErr <- err
+	}()
+	<-started
+
+	followerBody := make(chan []byte, 1)
+	go func() {
+		body, shared, err := g.do(context.Background(), "key", fn)
+		require.NoError(t, err)
+		require.True(t, shared)
+		followerBody <- body
+	}()
+
+	require.Eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return g.calls["key"] != nil && g.calls["key"].waiters == 2
+	}, time.Second, time.Millisecond)
+
+	cancelLeader()
+	require.ErrorIs(t, <-leaderErr, context.Canceled)
+	close(release)
+	require.Equal(t, "ok", string(<-followerBody))
+}
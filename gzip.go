@@ -0,0 +1,33 @@
+package scryfall
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// maybeGunzip returns a reader over the decompressed contents of r when r
+// starts with a gzip header, and over r unchanged otherwise. The returned
+// close function releases the decompressor.
+func maybeGunzip(r io.Reader) (io.Reader, func() error, error) {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	head, err := br.Peek(len(gzipMagic))
+	if err != nil && err != io.EOF {
+		return nil, nil, err
+	}
+	if !bytes.Equal(head, gzipMagic) {
+		return br, func() error { return nil }, nil
+	}
+	gz, err := gzip.NewReader(br)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open gzip stream: %w", err)
+	}
+	return gz, gz.Close, nil
+}
@@ -0,0 +1,121 @@
+package scryfall
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func gzipCards(t *testing.T, cards []Card) (plain, compressed []byte) {
+	t.Helper()
+	plain, err := json.Marshal(cards)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err = gz.Write(plain)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return plain, buf.Bytes()
+}
+
+func gzipServer(t *testing.T, compressed []byte) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("Content-Length", strconv.Itoa(len(compressed)))
+		_, _ = w.Write(compressed)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDownloadBulkDataStream_Gzip(t *testing.T) {
+	t.Parallel()
+
+	_, compressed := gzipCards(t, []Card{{ID: "card-1"}, {ID: "card-2"}})
+	server := gzipServer(t, compressed)
+	client := NewClient(WithLimiter(rate.NewLimiter(rate.Inf, 0)))
+
+	var seen []string
+	var current, total int64
+	err := client.DownloadBulkDataStream(context.Background(), server.URL, func(card Card) error {
+		seen = append(seen, card.ID)
+		return nil
+	}, func(c, t int64) {
+		current, total = c, t
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"card-1", "card-2"}, seen)
+	require.Equal(t, int64(len(compressed)), total)
+	require.Equal(t, int64(len(compressed)), current)
+}
+
+func TestDownloadToFile_Gzip(t *testing.T) {
+	t.Parallel()
+
+	plain, compressed := gzipCards(t, []Card{{ID: "card-1"}})
+	server := gzipServer(t, compressed)
+	client := NewClient(WithLimiter(rate.NewLimiter(rate.Inf, 0)))
+	dir := t.TempDir()
+
+	decoded := filepath.Join(dir, "cards.json")
+	require.NoError(t, client.DownloadToFile(context.Background(), server.URL, decoded, nil, WithJSONValidation()))
+	got, err := os.ReadFile(decoded)
+	require.NoError(t, err)
+	require.Equal(t, plain, got)
+
+	stored := filepath.Join(dir, "cards.json.gz")
+	require.NoError(t, client.DownloadToFile(context.Background(), server.URL, stored, nil, WithCompressedStorage(), WithJSONValidation()))
+	got, err = os.ReadFile(stored)
+	require.NoError(t, err)
+	require.Equal(t, compressed, got)
+
+	f, err := os.Open(stored)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+	var ids []string
+	require.NoError(t, client.ProcessBulkDataStream(f, func(card Card) error {
+		ids = append(ids, card.ID)
+		return nil
+	}))
+	require.Equal(t, []string{"card-1"}, ids)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+}
+
+func TestDownloadToFile_CompressesPlainTransfer(t *testing.T) {
+	t.Parallel()
+
+	plain, _ := gzipCards(t, []Card{{ID: "card-1"}})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(plain)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithLimiter(rate.NewLimiter(rate.Inf, 0)))
+	path := filepath.Join(t.TempDir(), "cards.json.gz")
+	require.NoError(t, client.DownloadToFile(context.Background(), server.URL, path, nil, WithCompressedStorage()))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(gz)
+	require.NoError(t, err)
+	require.Equal(t, plain, buf.Bytes())
+}
@@ -8,7 +8,7 @@ type ClientAPI interface {
 	GetCardByID(ctx context.Context, id string) (*Card, error)
 	ListBulkData(ctx context.Context) ([]CardBulkData, error)
 	ListSets(ctx context.Context) ([]CardSet, error)
-	GetBulkDataByType(ctx context.Context, bulkType string) (*CardBulkData, error)
+	GetBulkDataByType(ctx context.Context, bulkType BulkType) (*CardBulkData, error)
 	DownloadBulkDataStream(ctx context.Context, downloadURI string, cardCallback func(Card) error, progressFn ProgressFunc) error
 	DownloadBulkData(ctx context.Context, downloadURI string) ([]Card, error)
 }
@@ -0,0 +1,129 @@
+package scryfall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// errStopIteration is returned from internal callbacks when the consumer of
+// an iterator breaks out of its range loop.
+var errStopIteration = errors.New("scryfall: iteration stopped")
+
+// seqOf adapts a callback-driven producer to an iterator. A failure is
+// yielded once as the final element. Breaking out of the loop stops the
+// producer, which releases its resources before the loop statement returns.
+func seqOf[T any](run func(fn func(T) error) error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		stopped := false
+		err := run(func(v T) error {
+			if stopped || !yield(v, nil) {
+				stopped = true
+				return errStopIteration
+			}
+			return nil
+		})
+		// yield must never be called again once it has returned false,
+		// whatever error the producer reports for the early stop.
+		if err != nil && !stopped {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// BulkCards downloads and parses a bulk data file, yielding each card. It
+// accepts the same options as StreamBulkData. The download is closed when the
+// loop ends, including on break.
+func (c *Client) BulkCards(ctx context.Context, downloadURI string, opts ...BulkOption) iter.Seq2[Card, error] {
+	return seqOf(func(fn func(Card) error) error {
+		return c.StreamBulkData(ctx, downloadURI, fn, opts...)
+	})
+}
+
+// BulkStream yields each record of a bulk data array read from reader,
+// decoded into a T. It accepts the same options as ProcessBulkStream.
+func BulkStream[T any](ctx context.Context, reader io.Reader, opts ...BulkOption) iter.Seq2[T, error] {
+	return seqOf(func(fn func(T) error) error {
+		return ProcessBulkStream(ctx, reader, fn, opts...)
+	})
+}
+
+// BulkFileCards yields each card in a local bulk data file, which may be a
+// JSON array or newline-delimited JSON and may be gzip-compressed. The file
+// is closed when the loop ends.
+func BulkFileCards(ctx context.Context, path string, opts ...BulkOption) iter.Seq2[Card, error] {
+	return seqOf(func(fn func(Card) error) error {
+		f, err := OpenBulkFile(path, opts...)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		return f.Process(ctx, fn)
+	})
+}
+
+// listPage is a page of a paginated Scryfall list object.
+type listPage[T any] struct {
+	Data     []T    `json:"data"`
+	HasMore  bool   `json:"has_more"`
+	NextPage string `json:"next_page"`
+}
+
+// paginate yields every element of a paginated list starting at firstURL,
+// requesting the next page only once the current one has been consumed.
+func paginate[T any](ctx context.Context, c *Client, op Operation, firstURL string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		next := firstURL
+		for next != "" {
+			var page listPage[T]
+			if err := c.getURL(ctx, op, next, &page); err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, v := range page.Data {
+				if !yield(v, nil) {
+					return
+				}
+			}
+			next = ""
+			if page.HasMore {
+				next = page.NextPage
+			}
+		}
+	}
+}
+
+// SearchCards yields every card matching a Scryfall full-text search query,
+// fetching further pages on demand. A query with no matches yields nothing.
+func (c *Client) SearchCards(ctx context.Context, query string) iter.Seq2[Card, error] {
+	return func(yield func(Card, error) bool) {
+		if query == "" {
+			yield(Card{}, fmt.Errorf("search query is required"))
+			return
+		}
+		op := Operation{Name: OpSearchCards, Route: "/cards/search", Params: map[string]string{"q": query}}
+		base, err := c.resolve(op.path())
+		if err != nil {
+			yield(Card{}, err)
+			return
+		}
+		firstURL := base + "?" + url.Values{"q": {query}}.Encode()
+		for card, err := range paginate[Card](ctx, c, op, firstURL) {
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				return
+			}
+			if !yield(card, err) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package scryfall
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestBulkCards_BreakClosesDownload(t *testing.T) {
+	t.Parallel()
+
+	payload := bulkPayload(1000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(payload))
+	}))
+	t.Cleanup(server.Close)
+
+	tracer := NewInMemoryTracer()
+	client := NewClient(WithLimiter(rate.NewLimiter(rate.Inf, 0)), WithTracer(tracer))
+	var ids []string
+	for card, err := range client.BulkCards(context.Background(), server.URL) {
+		require.NoError(t, err)
+		ids = append(ids, card.ID)
+		if len(ids) == 3 {
+			break
+		}
+	}
+	require.Equal(t, []string{"card-0", "card-1", "card-2"}, ids)
+	for _, span := range tracer.Spans() {
+		require.Empty(t, span.Errors, span.Name)
+	}
+}
+
+func TestBulkCards_YieldsError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithLimiter(rate.NewLimiter(rate.Inf, 0)))
+	var errs []error
+	for _, err := range client.BulkCards(context.Background(), server.URL) {
+		errs = append(errs, err)
+	}
+	require.Len(t, errs, 1)
+	var dlErr *DownloadError
+	require.ErrorAs(t, errs[0], &dlErr)
+}
+
+func TestBulkFileCards(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cards.json.gz")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	gz := gzip.NewWriter(f)
+	_, err = gz.Write([]byte(bulkPayload(5)))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	require.NoError(t, f.Close())
+
+	var ids []string
+	for card, err := range BulkFileCards(context.Background(), path) {
+		require.NoError(t, err)
+		ids = append(ids, card.ID)
+	}
+	require.Len(t, ids, 5)
+
+	for _, err := range BulkFileCards(context.Background(), filepath.Join(t.TempDir(), "missing.json")) {
+		require.ErrorIs(t, err, os.ErrNotExist)
+	}
+}
+
+func TestBulkStream_Generic(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		ID string `json:"id"`
+	}
+	var ids []string
+	for r, err := range BulkStream[row](context.Background(), strings.NewReader(bulkPayload(3))) {
+		require.NoError(t, err)
+		ids = append(ids, r.ID)
+	}
+	require.Equal(t, []string{"card-0", "card-1", "card-2"}, ids)
+
+	var errs int
+	for _, err := range BulkStream[row](context.Background(), strings.NewReader(`[{"id":1}]`)) {
+		require.Error(t, err)
+		errs++
+	}
+	require.Equal(t, 1, errs)
+}
+
+func TestSearchCards_Paginates(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		require.Equal(t, "/cards/search", r.URL.Path)
+		page := r.URL.Query().Get("page")
+		switch page {
+		case "":
+			require.Equal(t, "t:goblin c:r", r.URL.Query().Get("q"))
+			_, _ = fmt.Fprintf(w, `{"object":"list","has_more":true,"next_page":"%s/cards/search?q=t%%3Agoblin+c%%3Ar&page=2","data":[{"id":"a"},{"id":"b"}]}`, server.URL)
+		case "2":
+			_, _ = w.Write([]byte(`{"object":"list","has_more":false,"data":[{"id":"c"}]}`))
+		default:
+			t.Errorf("unexpected page %q", page)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithBaseURL(server.URL), WithLimiter(rate.NewLimiter(rate.Inf, 0)))
+	var ids []string
+	for card, err := range client.SearchCards(context.Background(), "t:goblin c:r") {
+		require.NoError(t, err)
+		ids = append(ids, card.ID)
+	}
+	require.Equal(t, []string{"a", "b", "c"}, ids)
+
+	// Breaking early must not fetch the next page.
+	requests.Store(0)
+	for range client.SearchCards(context.Background(), "t:goblin c:r") {
+		break
+	}
+	require.Equal(t, int32(1), requests.Load())
+}
+
+func TestSearchCards_NoMatches(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"object":"error","status":404,"code":"not_found","details":"Your query didn't match any cards."}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithBaseURL(server.URL), WithLimiter(rate.NewLimiter(rate.Inf, 0)))
+	for _, err := range client.SearchCards(context.Background(), "nothing") {
+		t.Fatalf("unexpected element, err=%v", err)
+	}
+
+	for _, err := range client.SearchCards(context.Background(), "") {
+		require.True(t, err != nil && !errors.Is(err, errStopIteration))
+	}
+}
+
+func TestBulkStream_CancelAndBreak(t *testing.T) {
+	t.Parallel()
+
+	for _, opts := range [][]BulkOption{nil, {WithDecodeWorkers(4), WithPreserveOrder()}} {
+		ctx, cancel := context.WithCancel(context.Background())
+		var n int
+		require.NotPanics(t, func() {
+			for _, err := range BulkStream[Card](ctx, strings.NewReader(bulkPayload(100)), opts...) {
+				require.NoError(t, err)
+				n++
+				cancel()
+				break
+			}
+		})
+		require.Equal(t, 1, n)
+	}
+}
@@ -0,0 +1,96 @@
+package scryfall
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxReportedErrors caps the RecordErrors kept in a BulkReport when the
+// error budget is unlimited.
+const maxReportedErrors = 1000
+
+// ErrTooManyMalformed is returned when lenient parsing exceeds its error
+// budget.
+var ErrTooManyMalformed = errors.New("too many malformed bulk records")
+
+// RecordError describes a bulk record that could not be decoded.
+type RecordError struct {
+	// Index is the zero-based position of the record in the stream.
+	Index int64
+	// Offset is the byte offset of the record in the decompressed stream.
+	Offset int64
+	Err    error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("decode record %d at offset %d: %v", e.Index, e.Offset, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// BulkReport summarises a bulk stream. It is filled in when processing
+// returns, whether or not it succeeded.
+type BulkReport struct {
+	// Records is the number of records passed to the callback.
+	Records int64
+	// Skipped is the number of malformed records skipped in lenient mode.
+	Skipped int64
+	// Errors holds the skipped records, up to the error budget.
+	Errors []RecordError
+}
+
+// WithLenient skips records that fail to decode instead of aborting the
+// stream. Garbage between the elements of a JSON array is skipped up to the
+// next element and counted as one malformed record. Processing fails with
+// ErrTooManyMalformed once more than maxErrors records have been skipped;
+// zero or less means no limit. Use WithReport to inspect the skipped records.
+func WithLenient(maxErrors int) BulkOption {
+	return func(o *bulkOptions) {
+		o.lenient = true
+		o.maxErrors = max(maxErrors, 0)
+	}
+}
+
+// WithReport fills r with a summary of the stream when processing returns.
+func WithReport(r *BulkReport) BulkOption {
+	return func(o *bulkOptions) {
+		o.report = r
+	}
+}
+
+// recordTally applies the lenient error budget and collects skipped records.
+type recordTally struct {
+	lenient   bool
+	maxErrors int
+	skipped   int64
+	errors    []RecordError
+}
+
+func newRecordTally(o bulkOptions) *recordTally {
+	return &recordTally{lenient: o.lenient, maxErrors: o.maxErrors}
+}
+
+// bad is passed to decodeRecords; it is only called from the goroutine that
+// invokes the callback.
+func (t *recordTally) bad(rerr *RecordError) error {
+	if !t.lenient {
+		return rerr
+	}
+	t.skipped++
+	if t.maxErrors > 0 && t.skipped > int64(t.maxErrors) {
+		return fmt.Errorf("%w (%d skipped): %w", ErrTooManyMalformed, t.skipped, rerr)
+	}
+	if t.maxErrors > 0 || len(t.errors) < maxReportedErrors {
+		t.errors = append(t.errors, *rerr)
+	}
+	return nil
+}
+
+func (t *recordTally) report(r *BulkReport, records int64) {
+	if r == nil {
+		return
+	}
+	*r = BulkReport{Records: records, Skipped: t.skipped, Errors: t.errors}
+}
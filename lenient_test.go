@@ -0,0 +1,122 @@
+package scryfall
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const malformedPayload = `[{"id":"a"},{"id":12},{"id":"c"},{"id":["x"]},{"id":"e"}]`
+
+func TestProcessBulkDataStream_LenientSkipsMalformed(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient()
+	for _, extra := range [][]BulkOption{nil, {WithDecodeWorkers(3), WithPreserveOrder()}} {
+		var report BulkReport
+		var ids []string
+		opts := append([]BulkOption{WithLenient(5), WithReport(&report)}, extra...)
+		err := client.ProcessBulkDataStream(strings.NewReader(malformedPayload), func(card Card) error {
+			ids = append(ids, card.ID)
+			return nil
+		}, opts...)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "c", "e"}, ids)
+
+		require.Equal(t, int64(3), report.Records)
+		require.Equal(t, int64(2), report.Skipped)
+		require.Len(t, report.Errors, 2)
+		require.Equal(t, int64(1), report.Errors[0].Index)
+		require.Equal(t, int64(12), report.Errors[0].Offset)
+		require.Equal(t, int64(3), report.Errors[1].Index)
+		require.Equal(t, int64(33), report.Errors[1].Offset)
+		require.Equal(t, byte('{'), malformedPayload[report.Errors[1].Offset])
+		require.Error(t, report.Errors[0].Err)
+	}
+}
+
+func TestProcessBulkDataStream_LenientResynchronises(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		payload string
+		ids     []string
+		offsets []int64
+	}{
+		{`[{"id":"a"},{"id":"b"}},{"id":"c"}]`, []string{"a", "b", "c"}, []int64{22}},
+		{`[{"id":"a"} x, "y", {"id":"b"} {"id":"z"}]`, []string{"a", "b"}, []int64{12, 31}},
+		{`[{"id":"a"},, {"id":"b"}]`, []string{"a", "b"}, []int64{12}},
+	}
+	client := NewClient()
+	for _, tc := range cases {
+		for _, extra := range [][]BulkOption{nil, {WithDecodeWorkers(3), WithPreserveOrder()}} {
+			var report BulkReport
+			var ids []string
+			opts := append([]BulkOption{WithLenient(0), WithReport(&report)}, extra...)
+			err := client.ProcessBulkDataStream(strings.NewReader(tc.payload), func(card Card) error {
+				ids = append(ids, card.ID)
+				return nil
+			}, opts...)
+			require.NoError(t, err, tc.payload)
+			require.Equal(t, tc.ids, ids)
+			require.Equal(t, int64(len(tc.offsets)), report.Skipped)
+			for i, offset := range tc.offsets {
+				require.Equal(t, offset, report.Errors[i].Offset)
+			}
+		}
+	}
+
+	err := client.ProcessBulkDataStream(strings.NewReader(cases[0].payload), func(Card) error { return nil })
+	require.ErrorContains(t, err, "expected ',' or ']' after element at offset 22")
+}
+
+func TestProcessBulkDataStream_LenientBudgetExceeded(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient()
+	var report BulkReport
+	var ids []string
+	err := client.ProcessBulkDataStream(strings.NewReader(malformedPayload), func(card Card) error {
+		ids = append(ids, card.ID)
+		return nil
+	}, WithLenient(1), WithReport(&report))
+	require.ErrorIs(t, err, ErrTooManyMalformed)
+
+	var rerr *RecordError
+	require.ErrorAs(t, err, &rerr)
+	require.Equal(t, int64(3), rerr.Index)
+	require.Equal(t, []string{"a", "c"}, ids)
+	require.Equal(t, int64(2), report.Skipped)
+	require.Len(t, report.Errors, 1)
+}
+
+func TestProcessBulkStream_StrictReport(t *testing.T) {
+	t.Parallel()
+
+	var report BulkReport
+	err := ProcessBulkStream(context.Background(), strings.NewReader(malformedPayload), func(Card) error {
+		return nil
+	}, WithReport(&report))
+
+	var rerr *RecordError
+	require.ErrorAs(t, err, &rerr)
+	require.Equal(t, int64(1), rerr.Index)
+	require.Equal(t, BulkReport{Records: 1}, report)
+}
+
+func TestProcessBulkStream_LenientNDJSON(t *testing.T) {
+	t.Parallel()
+
+	input := "{\"id\":\"a\"}\n{\"id\":\n{\"id\":\"c\"}\n"
+	var report BulkReport
+	var ids []string
+	err := ProcessBulkStream(context.Background(), strings.NewReader(input), func(card Card) error {
+		ids = append(ids, card.ID)
+		return nil
+	}, WithBulkFormat(BulkFormatNDJSON), WithLenient(0), WithReport(&report))
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "c"}, ids)
+	require.Equal(t, int64(11), report.Errors[0].Offset)
+}
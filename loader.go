@@ -0,0 +1,166 @@
+package scryfall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const defaultBatchWindow = 10 * time.Millisecond
+
+// ErrCardNotFound is returned by CardLoader for IDs Scryfall reported as not
+// found in a collection lookup, and by IndexedBulkFile for IDs missing from
+// its index.
+var ErrCardNotFound = errors.New("card not found")
+
+// CardLoader batches individual card lookups into collection requests. Calls
+// to Load made within the batch window are combined into a single
+// /cards/collection request of up to MaxCollectionIdentifiers IDs.
+type CardLoader struct {
+	client   *Client
+	window   time.Duration
+	maxBatch int
+
+	mu    sync.Mutex
+	batch *loaderBatch
+}
+
+type loaderBatch struct {
+	ctx     context.Context
+	ids     []string
+	waiters map[string][]chan loaderResult
+	timer   *time.Timer
+}
+
+type loaderResult struct {
+	card *Card
+	err  error
+}
+
+// LoaderOption configures a CardLoader.
+type LoaderOption func(*CardLoader)
+
+// WithBatchWindow sets how long the loader waits for more IDs before
+// dispatching a partial batch.
+func WithBatchWindow(window time.Duration) LoaderOption {
+	return func(l *CardLoader) {
+		if window > 0 {
+			l.window = window
+		}
+	}
+}
+
+// WithMaxBatchSize caps the number of IDs per collection request. Values
+// above MaxCollectionIdentifiers are clamped.
+func WithMaxBatchSize(size int) LoaderOption {
+	return func(l *CardLoader) {
+		if size > 0 {
+			l.maxBatch = min(size, MaxCollectionIdentifiers)
+		}
+	}
+}
+
+// NewCardLoader constructs a batching loader on top of client.
+func NewCardLoader(client *Client, opts ...LoaderOption) *CardLoader {
+	l := &CardLoader{
+		client:   client,
+		window:   defaultBatchWindow,
+		maxBatch: MaxCollectionIdentifiers,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Load returns the card with the given Scryfall UUID. IDs Scryfall could not
+// resolve yield an error wrapping ErrCardNotFound.
+func (l *CardLoader) Load(ctx context.Context, id string) (*Card, error) {
+	if id == "" {
+		return nil, fmt.Errorf("card id is required")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ch := make(chan loaderResult, 1)
+	l.enqueue(ctx, id, ch)
+
+	select {
+	case res := <-ch:
+		return res.card, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (l *CardLoader) enqueue(ctx context.Context, id string, ch chan loaderResult) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.batch == nil {
+		b := &loaderBatch{
+			ctx:     context.WithoutCancel(ctx),
+			waiters: make(map[string][]chan loaderResult),
+		}
+		b.timer = time.AfterFunc(l.window, func() { l.flush(b) })
+		l.batch = b
+	}
+	b := l.batch
+	if _, ok := b.waiters[id]; !ok {
+		b.ids = append(b.ids, id)
+	}
+	b.waiters[id] = append(b.waiters[id], ch)
+
+	if len(b.ids) >= l.maxBatch {
+		b.timer.Stop()
+		l.batch = nil
+		go l.dispatch(b)
+	}
+}
+
+func (l *CardLoader) flush(b *loaderBatch) {
+	l.mu.Lock()
+	if l.batch != b {
+		l.mu.Unlock()
+		return
+	}
+	l.batch = nil
+	l.mu.Unlock()
+	l.dispatch(b)
+}
+
+func (l *CardLoader) dispatch(b *loaderBatch) {
+	identifiers := make([]CardIdentifier, len(b.ids))
+	for i, id := range b.ids {
+		identifiers[i] = CardIdentifier{ID: id}
+	}
+
+	collection, err := l.client.GetCardCollection(b.ctx, identifiers)
+	if err != nil {
+		for _, chans := range b.waiters {
+			for _, ch := range chans {
+				ch <- loaderResult{err: err}
+			}
+		}
+		return
+	}
+
+	found := make(map[string]*Card, len(collection.Data))
+	for i := range collection.Data {
+		found[collection.Data[i].ID] = &collection.Data[i]
+	}
+	for id, chans := range b.waiters {
+		card, ok := found[id]
+		for _, ch := range chans {
+			if !ok {
+				ch <- loaderResult{err: fmt.Errorf("card %s: %w", id, ErrCardNotFound)}
+				continue
+			}
+			cp := *card
+			ch <- loaderResult{card: &cp}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func newCollectionServer(t *testing.T, requests *int32, sizes chan<- int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/cards/collection", r.URL.Path)
+		require.Equal(t, http.MethodPost, r.Method)
+		atomic.AddInt32(requests, 1)
+
+		var payload struct {
+			Identifiers []CardIdentifier `json:"identifiers"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		if sizes != nil {
+			sizes <- len(payload.Identifiers)
+		}
+
+		var resp CardCollection
+		for _, ident := range payload.Identifiers {
+			if ident.ID == "missing" {
+				resp.NotFound = append(resp.NotFound, ident)
+				continue
+			}
+			resp.Data = append(resp.Data, Card{ID: ident.ID, Name: "Card " + ident.ID})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCardLoader_BatchesLookups(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	server := newCollectionServer(t, &requests, nil)
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+	)
+	loader := NewCardLoader(client, WithBatchWindow(50*time.Millisecond))
+
+	ids := []string{"a", "b", "c", "a", "missing"}
+	var wg sync.WaitGroup
+	results := make([]*Card, len(ids))
+	errs := make([]error, len(ids))
+	for i, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = loader.Load(context.Background(), id)
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	for i, id := range ids {
+		if id == "missing" {
+			require.ErrorIs(t, errs[i], ErrCardNotFound)
+			require.Nil(t, results[i])
+			continue
+		}
+		require.NoError(t, errs[i])
+		require.Equal(t, "Card "+id, results[i].Name)
+	}
+}
+
+func TestCardLoader_SplitsLargeBatches(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	sizes := make(chan int, 4)
+	server := newCollectionServer(t, &requests, sizes)
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+	)
+	loader := NewCardLoader(client, WithBatchWindow(time.Second))
+
+	var wg sync.WaitGroup
+	for i := 0; i < MaxCollectionIdentifiers+5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			card, err := loader.Load(context.Background(), fmt.Sprintf("id-%d", i))
+			require.NoError(t, err)
+			require.Equal(t, fmt.Sprintf("id-%d", i), card.ID)
+		}()
+	}
+	wg.Wait()
+	close(sizes)
+
+	var got []int
+	for size := range sizes {
+		got = append(got, size)
+	}
+	require.ElementsMatch(t, []int{MaxCollectionIdentifiers, 5}, got)
+}
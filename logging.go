@@ -0,0 +1,139 @@
+package scryfall
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// Logger is the minimal structured logger used by the client. Key/value
+// pairs follow the alternating key, value convention shared by log/slog and
+// charmbracelet/log. *slog.Logger satisfies Logger directly.
+type Logger interface {
+	Debug(msg string, keyvals ...any)
+	Info(msg string, keyvals ...any)
+	Warn(msg string, keyvals ...any)
+	Error(msg string, keyvals ...any)
+}
+
+// NewCharmLogger adapts a charmbracelet logger to Logger.
+func NewCharmLogger(logger *log.Logger) Logger {
+	return charmLogger{logger: logger}
+}
+
+type charmLogger struct {
+	logger *log.Logger
+}
+
+func (l charmLogger) Debug(msg string, keyvals ...any) { l.logger.Debug(msg, keyvals...) }
+func (l charmLogger) Info(msg string, keyvals ...any)  { l.logger.Info(msg, keyvals...) }
+func (l charmLogger) Warn(msg string, keyvals ...any)  { l.logger.Warn(msg, keyvals...) }
+func (l charmLogger) Error(msg string, keyvals ...any) { l.logger.Error(msg, keyvals...) }
+
+// NewSlogLogger adapts a log/slog logger to Logger. A nil logger uses
+// slog.Default.
+func NewSlogLogger(logger *slog.Logger) Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return logger
+}
+
+// WithSlogLogger sets a log/slog logger.
+func WithSlogLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = NewSlogLogger(logger)
+		}
+	}
+}
+
+// WithCustomLogger sets any Logger implementation.
+func WithCustomLogger(logger Logger) Option {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+type attemptContextKey struct{}
+
+// attemptFromContext returns the retry attempt recorded in ctx, starting at 1.
+func attemptFromContext(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptContextKey{}).(int); ok && attempt > 0 {
+		return attempt
+	}
+	return 1
+}
+
+// logRequest emits the per-request log line shared by every operation.
+// Failures and server-side errors log at warn, everything else at debug.
+func (c *Client) logRequest(ctx context.Context, op Operation, url string, status int, duration time.Duration, err error) {
+	keyvals := traceFields(ctx,
+		"operation", op.Name,
+		"url", url,
+		"status", status,
+		"duration", duration,
+		"attempt", attemptFromContext(ctx),
+	)
+	switch {
+	case err != nil:
+		c.logger.Warn("scryfall request failed", append(keyvals, "error", err)...)
+	case status >= http.StatusInternalServerError || status == http.StatusTooManyRequests:
+		c.logger.Warn("scryfall request", keyvals...)
+	default:
+		c.logger.Debug("scryfall request", keyvals...)
+	}
+}
+
+// downloadSummary records the latest attempt of a bulk download and the
+// status it received, for the download's final log line.
+type downloadSummary struct {
+	attempt int
+	status  int
+}
+
+// record notes the status of the response, or of the DownloadError, that
+// opening the download produced.
+func (s *downloadSummary) record(resp *http.Response, err error) {
+	var dlErr *DownloadError
+	switch {
+	case resp != nil:
+		s.status = resp.StatusCode
+	case errors.As(err, &dlErr):
+		s.status = dlErr.StatusCode
+	default:
+		s.status = 0
+	}
+}
+
+// logDownload logs the start of a bulk download and returns a function that
+// logs its outcome, to be deferred with a pointer to the caller's error. The
+// outcome reports the attempt and status held in sum at that point.
+func (c *Client) logDownload(ctx context.Context, op Operation, url string, sum *downloadSummary) func(*error) {
+	start := time.Now()
+	c.logger.Info("scryfall download started", traceFields(ctx, "operation", op.Name, "url", url)...)
+	return func(errp *error) {
+		keyvals := traceFields(ctx,
+			"operation", op.Name,
+			"url", url,
+			"status", sum.status,
+			"duration", time.Since(start),
+			"attempt", max(sum.attempt, 1),
+		)
+		if errors.Is(*errp, errStopIteration) {
+			c.logger.Info("scryfall download stopped by caller", keyvals...)
+			return
+		}
+		if *errp != nil {
+			c.logger.Error("scryfall download failed", append(keyvals, "error", *errp)...)
+			return
+		}
+		c.logger.Info("scryfall download finished", keyvals...)
+	}
+}
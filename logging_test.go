@@ -0,0 +1,91 @@
+package scryfall
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestWithSlogLogger_ConsistentRequestFields(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/bulk.json" {
+			require.NoError(t, json.NewEncoder(w).Encode([]Card{{ID: "card-1"}}))
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(Card{ID: "abc-123"}))
+	}))
+	t.Cleanup(server.Close)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+		WithSlogLogger(logger),
+	)
+
+	ctx := context.Background()
+	_, err := client.GetCardByID(ctx, "abc-123")
+	require.NoError(t, err)
+	require.NoError(t, client.DownloadBulkDataStream(ctx, server.URL+"/bulk.json", func(Card) error {
+		return nil
+	}, nil))
+	require.NoError(t, client.DownloadToFile(ctx, server.URL+"/bulk.json", filepath.Join(t.TempDir(), "bulk.json"), nil))
+
+	operations := map[string]bool{}
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		if entry["msg"] != "scryfall request" {
+			continue
+		}
+		for _, key := range []string{"operation", "url", "status", "duration", "attempt"} {
+			require.Contains(t, entry, key)
+		}
+		operations[entry["operation"].(string)] = true
+	}
+	require.Equal(t, map[string]bool{
+		OpGetCardByID:            true,
+		OpDownloadBulkDataStream: true,
+		OpDownloadToFile:         true,
+	}, operations)
+}
+
+func TestDownloadToFile_LogsFinalAttempt(t *testing.T) {
+	t.Parallel()
+
+	var ranges []string
+	server := flakyRangeServer(t, 2, &ranges)
+	var buf bytes.Buffer
+	client := NewClient(
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+		WithSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+	)
+	require.NoError(t, client.DownloadToFile(context.Background(), server.URL, filepath.Join(t.TempDir(), "bulk.json"), nil))
+
+	var finished map[string]any
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		if entry["msg"] == "scryfall download finished" {
+			finished = entry
+		}
+	}
+	require.NotNil(t, finished)
+	require.Equal(t, float64(3), finished["attempt"])
+	require.Equal(t, float64(http.StatusPartialContent), finished["status"])
+}
@@ -0,0 +1,264 @@
+package scryfall
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MetricsHook receives instrumentation events from the client. All methods
+// must be safe for concurrent use. A status of zero passed to ObserveRequest
+// indicates the request failed before a response was received.
+type MetricsHook interface {
+	ObserveRequest(op string, status int, duration time.Duration)
+	ObserveLimiterWait(op string, wait time.Duration)
+	AddBytesDownloaded(op string, n int64)
+	IncCacheHit(op string)
+	IncCoalesced(op string)
+}
+
+// WithMetrics installs a metrics hook on the client.
+func WithMetrics(hook MetricsHook) Option {
+	return func(c *Client) {
+		if hook != nil {
+			c.metrics = hook
+		}
+	}
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveRequest(string, int, time.Duration) {}
+func (noopMetrics) ObserveLimiterWait(string, time.Duration)  {}
+func (noopMetrics) AddBytesDownloaded(string, int64)          {}
+func (noopMetrics) IncCacheHit(string)                        {}
+func (noopMetrics) IncCoalesced(string)                       {}
+
+// DefaultLatencyBuckets are the histogram bounds, in seconds, used by
+// NewMetrics.
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Metrics is an in-process MetricsHook that can be scraped in Prometheus text
+// format via Handler or published with expvar.Publish.
+type Metrics struct {
+	buckets []float64
+
+	mu          sync.Mutex
+	requests    map[requestKey]int64
+	latency     map[string]*histogram
+	limiterWait map[string]*histogram
+	bytes       map[string]int64
+	cacheHits   map[string]int64
+	coalesced   map[string]int64
+}
+
+type requestKey struct {
+	op     string
+	status string
+}
+
+type histogram struct {
+	counts []int64
+	sum    float64
+	count  int64
+}
+
+// NewMetrics constructs a Metrics collector using DefaultLatencyBuckets.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		buckets:     DefaultLatencyBuckets,
+		requests:    make(map[requestKey]int64),
+		latency:     make(map[string]*histogram),
+		limiterWait: make(map[string]*histogram),
+		bytes:       make(map[string]int64),
+		cacheHits:   make(map[string]int64),
+		coalesced:   make(map[string]int64),
+	}
+}
+
+// ObserveRequest implements MetricsHook.
+func (m *Metrics) ObserveRequest(op string, status int, duration time.Duration) {
+	label := "error"
+	if status > 0 {
+		label = strconv.Itoa(status)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[requestKey{op: op, status: label}]++
+	m.observe(m.latency, op, duration)
+}
+
+// ObserveLimiterWait implements MetricsHook.
+func (m *Metrics) ObserveLimiterWait(op string, wait time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observe(m.limiterWait, op, wait)
+}
+
+// AddBytesDownloaded implements MetricsHook.
+func (m *Metrics) AddBytesDownloaded(op string, n int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bytes[op] += n
+}
+
+// IncCacheHit implements MetricsHook.
+func (m *Metrics) IncCacheHit(op string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cacheHits[op]++
+}
+
+// IncCoalesced implements MetricsHook.
+func (m *Metrics) IncCoalesced(op string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.coalesced[op]++
+}
+
+func (m *Metrics) observe(set map[string]*histogram, op string, d time.Duration) {
+	h, ok := set[op]
+	if !ok {
+		h = &histogram{counts: make([]int64, len(m.buckets))}
+		set[op] = h
+	}
+	seconds := d.Seconds()
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// Handler serves the collected metrics in Prometheus text exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = m.WritePrometheus(w)
+	})
+}
+
+// WritePrometheus writes the collected metrics in Prometheus text format.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+
+	writeHeader(bw, "scryfall_requests_total", "counter", "Scryfall HTTP requests by operation and status.")
+	keys := make([]requestKey, 0, len(m.requests))
+	for k := range m.requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].op != keys[j].op {
+			return keys[i].op < keys[j].op
+		}
+		return keys[i].status < keys[j].status
+	})
+	for _, k := range keys {
+		fmt.Fprintf(bw, "scryfall_requests_total{operation=%q,status=%q} %d\n", k.op, k.status, m.requests[k])
+	}
+
+	m.writeHistograms(bw, "scryfall_request_duration_seconds", "Scryfall HTTP request latency.", m.latency)
+	m.writeHistograms(bw, "scryfall_limiter_wait_seconds", "Time spent waiting on the rate limiter.", m.limiterWait)
+	writeCounters(bw, "scryfall_download_bytes_total", "Bytes read from bulk data downloads.", m.bytes)
+	writeCounters(bw, "scryfall_cache_hits_total", "API responses served from the cache.", m.cacheHits)
+	writeCounters(bw, "scryfall_coalesced_requests_total", "Calls that joined an identical in-flight request.", m.coalesced)
+
+	return bw.Flush()
+}
+
+// String renders the metrics as JSON so a Metrics value satisfies expvar.Var.
+func (m *Metrics) String() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	type histogramJSON struct {
+		Count int64   `json:"count"`
+		Sum   float64 `json:"sum_seconds"`
+	}
+	histograms := func(set map[string]*histogram) map[string]histogramJSON {
+		out := make(map[string]histogramJSON, len(set))
+		for op, h := range set {
+			out[op] = histogramJSON{Count: h.count, Sum: h.sum}
+		}
+		return out
+	}
+	requests := make(map[string]map[string]int64)
+	for k, v := range m.requests {
+		if requests[k.op] == nil {
+			requests[k.op] = make(map[string]int64)
+		}
+		requests[k.op][k.status] = v
+	}
+
+	out, err := json.Marshal(map[string]any{
+		"requests":           requests,
+		"request_duration":   histograms(m.latency),
+		"limiter_wait":       histograms(m.limiterWait),
+		"download_bytes":     m.bytes,
+		"cache_hits":         m.cacheHits,
+		"coalesced_requests": m.coalesced,
+	})
+	if err != nil {
+		return "{}"
+	}
+	return string(out)
+}
+
+func (m *Metrics) writeHistograms(w io.Writer, name, help string, set map[string]*histogram) {
+	writeHeader(w, name, "histogram", help)
+	for _, op := range sortedKeys(set) {
+		h := set[op]
+		for i, bound := range m.buckets {
+			fmt.Fprintf(w, "%s_bucket{operation=%q,le=%q} %d\n", name, op, strconv.FormatFloat(bound, 'g', -1, 64), h.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket{operation=%q,le=\"+Inf\"} %d\n", name, op, h.count)
+		fmt.Fprintf(w, "%s_sum{operation=%q} %g\n", name, op, h.sum)
+		fmt.Fprintf(w, "%s_count{operation=%q} %d\n", name, op, h.count)
+	}
+}
+
+func writeCounters(w io.Writer, name, help string, set map[string]int64) {
+	writeHeader(w, name, "counter", help)
+	for _, op := range sortedKeys(set) {
+		fmt.Fprintf(w, "%s{operation=%q} %d\n", name, op, set[op])
+	}
+}
+
+func writeHeader(w io.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// countingReader reports bytes read from a download body to the metrics hook.
+type countingReader struct {
+	io.Reader
+	op      string
+	metrics MetricsHook
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		r.metrics.AddBytesDownloaded(r.op, int64(n))
+	}
+	return n, err
+}
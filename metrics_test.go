@@ -0,0 +1,64 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestMetrics_PrometheusExport(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cards/limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/bulk.json":
+			require.NoError(t, json.NewEncoder(w).Encode([]Card{{ID: "card-1"}}))
+		default:
+			require.NoError(t, json.NewEncoder(w).Encode(Card{ID: "abc-123"}))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	metrics := NewMetrics()
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+		WithMetrics(metrics),
+	)
+
+	_, err := client.GetCardByID(context.Background(), "abc-123")
+	require.NoError(t, err)
+	_, err = client.GetCardByID(context.Background(), "limited")
+	require.Error(t, err)
+	require.NoError(t, client.DownloadBulkDataStream(context.Background(), server.URL+"/bulk.json", func(Card) error {
+		return nil
+	}, nil))
+
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	text := string(body)
+
+	require.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+	require.Contains(t, text, `scryfall_requests_total{operation="GetCardByID",status="200"} 1`)
+	require.Contains(t, text, `scryfall_requests_total{operation="GetCardByID",status="429"} 1`)
+	require.Contains(t, text, `scryfall_request_duration_seconds_count{operation="GetCardByID"} 2`)
+	require.Contains(t, text, `scryfall_limiter_wait_seconds_count{operation="GetCardByID"} 2`)
+	require.Contains(t, text, `scryfall_download_bytes_total{operation="DownloadBulkDataStream"}`)
+	require.Contains(t, text, "# TYPE scryfall_request_duration_seconds histogram")
+
+	var _ expvar.Var = metrics
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal([]byte(metrics.String()), &decoded))
+	require.Contains(t, decoded, "requests")
+}
@@ -0,0 +1,109 @@
+package scryfall
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Operation names reported to middlewares.
+const (
+	OpGetCardByID            = "GetCardByID"
+	OpGetCardCollection      = "GetCardCollection"
+	OpListBulkData           = "ListBulkData"
+	OpGetBulkDataByType      = "GetBulkDataByType"
+	OpListSets               = "ListSets"
+	OpSearchCards            = "SearchCards"
+	OpDownloadBulkDataStream = "DownloadBulkDataStream"
+	OpDownloadToFile         = "DownloadToFile"
+)
+
+// Operation describes the API operation a request is made on behalf of.
+type Operation struct {
+	// Name is the client method, e.g. OpGetCardByID.
+	Name string
+	// Route is the templated API path, e.g. "/cards/{id}". Bulk downloads
+	// use the absolute download URI.
+	Route string
+	// Params holds the operation's arguments. Those named in Route are
+	// substituted into it.
+	Params map[string]string
+}
+
+// path expands Route using Params, escaping each value as a path segment.
+func (op Operation) path() string {
+	if len(op.Params) == 0 {
+		return op.Route
+	}
+	pairs := make([]string, 0, 2*len(op.Params))
+	for k, v := range op.Params {
+		pairs = append(pairs, "{"+k+"}", url.PathEscape(v))
+	}
+	return strings.NewReplacer(pairs...).Replace(op.Route)
+}
+
+// RoundTripFunc performs a single HTTP exchange for an operation.
+type RoundTripFunc func(op Operation, req *http.Request) (*http.Response, error)
+
+// Middleware intercepts requests and responses. Implementations call next to
+// continue the chain and may inspect or replace the request and response.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware appends middlewares to the client's request chain. The first
+// middleware supplied is the outermost.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *Client) {
+		for _, mw := range middlewares {
+			if mw != nil {
+				c.middlewares = append(c.middlewares, mw)
+			}
+		}
+	}
+}
+
+// do sends req through the middleware chain and then httpClient. ctx is the
+// caller's context, which may outlive req's own when the request carries an
+// extra deadline or cancellation.
+func (c *Client) do(ctx context.Context, httpClient *http.Client, op Operation, req *http.Request) (*http.Response, error) {
+	next := func(_ Operation, req *http.Request) (*http.Response, error) {
+		return httpClient.Do(req)
+	}
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		next = c.middlewares[i](next)
+	}
+
+	var breakerDone func(int, error)
+	if c.breaker != nil {
+		var err error
+		if breakerDone, err = c.breaker.allow(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, span := c.startSpan(req.Context(), SpanHTTP,
+		Attribute{Key: "http.method", Value: req.Method},
+		Attribute{Key: "http.url", Value: req.URL.String()},
+	)
+	req = req.WithContext(ctx)
+	if traceID, spanID := span.TraceID(), span.SpanID(); len(traceID) == 32 && len(spanID) == 16 {
+		req.Header.Set("Traceparent", "00-"+traceID+"-"+spanID+"-01")
+	}
+
+	start := time.Now()
+	resp, err := next(op, req)
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+		span.SetAttributes(Attribute{Key: "http.status_code", Value: status})
+	}
+	duration := time.Since(start)
+	if breakerDone != nil {
+		breakerDone(status, err)
+	}
+	c.metrics.ObserveRequest(op.Name, status, duration)
+	c.logRequest(ctx, op, req.URL.String(), status, duration, err)
+	endSpan(span, err)
+	return resp, err
+}
@@ -0,0 +1,78 @@
+package scryfall
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestWithMiddleware_SeesOperations(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "injected", r.Header.Get("X-Test"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/cards/abc-123":
+			require.NoError(t, json.NewEncoder(w).Encode(Card{ID: "abc-123"}))
+		default:
+			require.NoError(t, json.NewEncoder(w).Encode([]Card{{ID: "card-1"}}))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	var mu sync.Mutex
+	var order []string
+	var ops []Operation
+	var statuses []int
+	record := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(op Operation, req *http.Request) (*http.Response, error) {
+				mu.Lock()
+				order = append(order, name)
+				mu.Unlock()
+				return next(op, req)
+			}
+		}
+	}
+	inspect := func(next RoundTripFunc) RoundTripFunc {
+		return func(op Operation, req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Test", "injected")
+			resp, err := next(op, req)
+			mu.Lock()
+			ops = append(ops, op)
+			if resp != nil {
+				statuses = append(statuses, resp.StatusCode)
+			}
+			mu.Unlock()
+			return resp, err
+		}
+	}
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+		WithMiddleware(record("outer"), record("inner"), inspect),
+	)
+
+	_, err := client.GetCardByID(context.Background(), "abc-123")
+	require.NoError(t, err)
+	require.NoError(t, client.DownloadBulkDataStream(context.Background(), server.URL+"/bulk.json", func(Card) error {
+		return nil
+	}, nil))
+
+	require.Equal(t, []string{"outer", "inner", "outer", "inner"}, order)
+	require.Len(t, ops, 2)
+	require.Equal(t, OpGetCardByID, ops[0].Name)
+	require.Equal(t, "/cards/{id}", ops[0].Route)
+	require.Equal(t, "abc-123", ops[0].Params["id"])
+	require.Equal(t, OpDownloadBulkDataStream, ops[1].Name)
+	require.Equal(t, server.URL+"/bulk.json", ops[1].Route)
+	require.Equal(t, []int{http.StatusOK, http.StatusOK}, statuses)
+}
@@ -100,3 +100,13 @@ type CardSet struct {
 	FoilOnly    bool   `json:"foil_only"`
 	IconSVGURI  string `json:"icon_svg_uri"`
 }
+
+// Ruling represents a Scryfall ruling object, as found in the rulings bulk
+// export and the /cards/{id}/rulings endpoint.
+type Ruling struct {
+	Object      string `json:"object"`
+	OracleID    string `json:"oracle_id"`
+	Source      string `json:"source"`
+	PublishedAt string `json:"published_at"`
+	Comment     string `json:"comment"`
+}
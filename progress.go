@@ -0,0 +1,185 @@
+package scryfall
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+const defaultProgressInterval = 250 * time.Millisecond
+
+// ProgressPhase identifies the stage a bulk operation is in.
+type ProgressPhase string
+
+const (
+	// PhaseDownloading covers the network transfer, including decoding for
+	// streamed downloads.
+	PhaseDownloading ProgressPhase = "downloading"
+	// PhaseDecoding covers parsing of local bulk data.
+	PhaseDecoding ProgressPhase = "decoding"
+	// PhaseFinalizing covers verification and conversion of a downloaded file.
+	PhaseFinalizing ProgressPhase = "finalizing"
+	// PhaseDone is reported once when the operation completes successfully.
+	PhaseDone ProgressPhase = "done"
+)
+
+// Progress is a snapshot of a bulk download or parse.
+type Progress struct {
+	Phase ProgressPhase
+	// Bytes is the number of bytes transferred or read so far.
+	Bytes int64
+	// Total is the expected number of bytes, or -1 when unknown.
+	Total int64
+	// Rate is the average throughput in bytes per second.
+	Rate float64
+	// ETA estimates the time remaining, or zero when Total is unknown.
+	ETA time.Duration
+	// Cards is the number of cards decoded so far.
+	Cards   int64
+	Elapsed time.Duration
+}
+
+// ProgressHandler receives progress snapshots. Calls are throttled to the
+// configured progress interval, with phase changes always delivered.
+type ProgressHandler func(Progress)
+
+// WithProgress registers a handler for throttled progress events.
+func WithProgress(handler ProgressHandler) BulkOption {
+	return func(o *bulkOptions) {
+		o.progress = handler
+	}
+}
+
+// WithProgressInterval sets the minimum time between progress events.
+// Defaults to 250ms.
+func WithProgressInterval(interval time.Duration) BulkOption {
+	return func(o *bulkOptions) {
+		if interval > 0 {
+			o.progressInterval = interval
+		}
+	}
+}
+
+// WithExpectedSize supplies the total used for progress when the server does
+// not report a Content-Length, typically CardBulkData.CompressedSize. When
+// the transfer is gzip-encoded, DownloadToFile also rejects a download that
+// does not end up this size.
+func WithExpectedSize(size int64) BulkOption {
+	return func(o *bulkOptions) {
+		if size > 0 {
+			o.expectedSize = size
+		}
+	}
+}
+
+// progressTracker accumulates byte and card counts and delivers throttled
+// Progress events to a handler.
+type progressTracker struct {
+	handler  ProgressHandler
+	interval time.Duration
+	start    time.Time
+
+	mu         sync.Mutex
+	phase      ProgressPhase
+	bytes      int64
+	startBytes int64
+	total      int64
+	cards      int64
+	lastEmit   time.Time
+}
+
+// newProgressTracker returns nil when handler is nil; all methods are no-ops
+// on a nil tracker.
+func newProgressTracker(handler ProgressHandler, interval time.Duration, phase ProgressPhase) *progressTracker {
+	if handler == nil {
+		return nil
+	}
+	return &progressTracker{
+		handler:  handler,
+		interval: interval,
+		start:    time.Now(),
+		phase:    phase,
+		total:    -1,
+	}
+}
+
+// reset starts a new transfer at offset bytes of total.
+func (t *progressTracker) reset(offset, total int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.start = time.Now()
+	t.bytes, t.startBytes, t.total = offset, offset, total
+}
+
+func (t *progressTracker) addBytes(n int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.bytes += n
+	t.emitLocked(false)
+	t.mu.Unlock()
+}
+
+func (t *progressTracker) addCard() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.cards++
+	t.emitLocked(false)
+	t.mu.Unlock()
+}
+
+func (t *progressTracker) setPhase(phase ProgressPhase) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	if t.phase != phase {
+		t.phase = phase
+		t.emitLocked(true)
+	}
+	t.mu.Unlock()
+}
+
+func (t *progressTracker) emitLocked(force bool) {
+	now := time.Now()
+	if !force && now.Sub(t.lastEmit) < t.interval {
+		return
+	}
+	t.lastEmit = now
+
+	elapsed := now.Sub(t.start)
+	p := Progress{
+		Phase:   t.phase,
+		Bytes:   t.bytes,
+		Total:   t.total,
+		Cards:   t.cards,
+		Elapsed: elapsed,
+	}
+	if transferred := t.bytes - t.startBytes; elapsed > 0 && transferred > 0 {
+		p.Rate = float64(transferred) / elapsed.Seconds()
+		if t.total > t.bytes {
+			p.ETA = time.Duration(float64(t.total-t.bytes) / p.Rate * float64(time.Second))
+		}
+	}
+	t.handler(p)
+}
+
+// trackingReader reports bytes read to a progress tracker.
+type trackingReader struct {
+	io.Reader
+	tracker *progressTracker
+}
+
+func (r *trackingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		r.tracker.addBytes(int64(n))
+	}
+	return n, err
+}
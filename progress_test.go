@@ -0,0 +1,122 @@
+package scryfall
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestProgressTracker_Throttles(t *testing.T) {
+	t.Parallel()
+
+	var events []Progress
+	tracker := newProgressTracker(func(p Progress) { events = append(events, p) }, time.Hour, PhaseDownloading)
+	tracker.reset(0, 100)
+	for range 10 {
+		tracker.addBytes(10)
+	}
+	require.Len(t, events, 1, "only the first event should pass the throttle")
+
+	tracker.setPhase(PhaseDone)
+	require.Len(t, events, 2, "phase changes bypass the throttle")
+	require.Equal(t, PhaseDone, events[1].Phase)
+	require.Equal(t, int64(100), events[1].Bytes)
+}
+
+func TestProgressTracker_NilSafe(t *testing.T) {
+	t.Parallel()
+
+	tracker := newProgressTracker(nil, time.Second, PhaseDownloading)
+	require.Nil(t, tracker)
+	tracker.reset(0, 10)
+	tracker.addBytes(5)
+	tracker.addCard()
+	tracker.setPhase(PhaseDone)
+}
+
+func TestStreamBulkData_ProgressWithExpectedSize(t *testing.T) {
+	t.Parallel()
+
+	cards := make([]string, 50)
+	for i := range cards {
+		cards[i] = fmt.Sprintf(`{"id":"card-%d","name":"Card %d"}`, i, i)
+	}
+	payload := "[" + strings.Join(cards, ",") + "]"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Flushing before the body is complete forces a chunked response
+		// without a Content-Length.
+		_, _ = w.Write([]byte(payload[:len(payload)/2]))
+		w.(http.Flusher).Flush()
+		time.Sleep(10 * time.Millisecond)
+		_, _ = w.Write([]byte(payload[len(payload)/2:]))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithLimiter(rate.NewLimiter(rate.Inf, 0)))
+
+	var mu sync.Mutex
+	var events []Progress
+	var count int
+	err := client.StreamBulkData(context.Background(), server.URL, func(Card) error {
+		count++
+		return nil
+	},
+		WithExpectedSize(int64(len(payload))),
+		WithProgressInterval(time.Nanosecond),
+		WithProgress(func(p Progress) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, p)
+		}),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 50, count)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, events)
+	last := events[len(events)-1]
+	require.Equal(t, PhaseDone, last.Phase)
+	require.Equal(t, int64(len(payload)), last.Total)
+	require.Equal(t, int64(len(payload)), last.Bytes)
+	require.Equal(t, int64(50), last.Cards)
+	require.Positive(t, last.Rate)
+
+	var sawETA bool
+	for _, p := range events {
+		if p.ETA > 0 {
+			sawETA = true
+		}
+	}
+	require.True(t, sawETA, "expected an ETA while the transfer was incomplete")
+}
+
+func TestDownloadToFile_ProgressPhases(t *testing.T) {
+	t.Parallel()
+
+	var ranges []string
+	server := flakyRangeServer(t, 0, &ranges)
+	client := NewClient(WithLimiter(rate.NewLimiter(rate.Inf, 0)))
+
+	var phases []ProgressPhase
+	path := filepath.Join(t.TempDir(), "bulk.json")
+	err := client.DownloadToFile(context.Background(), server.URL, path, nil,
+		WithProgress(func(p Progress) {
+			if len(phases) == 0 || phases[len(phases)-1] != p.Phase {
+				phases = append(phases, p.Phase)
+			}
+		}),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []ProgressPhase{PhaseDownloading, PhaseFinalizing, PhaseDone}, phases)
+}
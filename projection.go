@@ -0,0 +1,150 @@
+package scryfall
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var errMalformedRecord = errors.New("malformed bulk record")
+
+// WithFields restricts bulk decoding to the named top-level JSON fields, such
+// as "id", "set", "collector_number" and "prices". Other fields are skipped
+// before decoding, so nested maps and slices the caller does not need are
+// never allocated. Names match keys case-insensitively, just as
+// encoding/json binds keys to struct fields, so "id" also keeps "ID".
+func WithFields(fields ...string) BulkOption {
+	return func(o *bulkOptions) {
+		if len(fields) == 0 {
+			o.fields = nil
+			return
+		}
+		o.fields = make(map[string]bool, len(fields))
+		for _, f := range fields {
+			o.fields[strings.ToLower(f)] = true
+		}
+	}
+}
+
+// projectFields returns a copy of the JSON object raw containing only the
+// top-level members whose lowercased names are in keep. Values that are not
+// objects are returned unchanged for the decoder to reject.
+func projectFields(raw []byte, keep map[string]bool) ([]byte, error) {
+	i := skipSpaceBytes(raw, 0)
+	if i >= len(raw) || raw[i] != '{' {
+		return raw, nil
+	}
+	out := make([]byte, 0, 256)
+	out = append(out, '{')
+	i++
+	for {
+		i = skipSpaceBytes(raw, i)
+		if i >= len(raw) {
+			return nil, errMalformedRecord
+		}
+		if raw[i] == '}' {
+			return append(out, '}'), nil
+		}
+		if raw[i] != '"' {
+			return nil, errMalformedRecord
+		}
+		keyEnd, err := skipValueBytes(raw, i)
+		if err != nil {
+			return nil, err
+		}
+		key := raw[i+1 : keyEnd-1]
+		name := string(key)
+		if bytes.IndexByte(key, '\\') >= 0 {
+			if err := json.Unmarshal(raw[i:keyEnd], &name); err != nil {
+				return nil, err
+			}
+		}
+		keyStart := i
+
+		i = skipSpaceBytes(raw, keyEnd)
+		if i >= len(raw) || raw[i] != ':' {
+			return nil, errMalformedRecord
+		}
+		i = skipSpaceBytes(raw, i+1)
+		valueEnd, err := skipValueBytes(raw, i)
+		if err != nil {
+			return nil, err
+		}
+		if keep[strings.ToLower(name)] {
+			if len(out) > 1 {
+				out = append(out, ',')
+			}
+			out = append(out, raw[keyStart:keyEnd]...)
+			out = append(out, ':')
+			out = append(out, raw[i:valueEnd]...)
+		}
+
+		i = skipSpaceBytes(raw, valueEnd)
+		if i >= len(raw) {
+			return nil, errMalformedRecord
+		}
+		switch raw[i] {
+		case ',':
+			i++
+		case '}':
+		default:
+			return nil, errMalformedRecord
+		}
+	}
+}
+
+func skipSpaceBytes(data []byte, i int) int {
+	for i < len(data) && isSpace(data[i]) {
+		i++
+	}
+	return i
+}
+
+// skipValueBytes returns the index just past the JSON value starting at i.
+func skipValueBytes(data []byte, i int) (int, error) {
+	if i >= len(data) {
+		return 0, errMalformedRecord
+	}
+	switch data[i] {
+	case '"':
+		for j := i + 1; j < len(data); j++ {
+			switch data[j] {
+			case '\\':
+				j++
+			case '"':
+				return j + 1, nil
+			}
+		}
+		return 0, errMalformedRecord
+	case '{', '[':
+		depth := 0
+		for j := i; j < len(data); j++ {
+			switch data[j] {
+			case '"':
+				end, err := skipValueBytes(data, j)
+				if err != nil {
+					return 0, err
+				}
+				j = end - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return j + 1, nil
+				}
+			}
+		}
+		return 0, errMalformedRecord
+	default:
+		j := i
+		for j < len(data) && !isSpace(data[j]) && data[j] != ',' && data[j] != '}' && data[j] != ']' {
+			j++
+		}
+		if j == i {
+			return 0, errMalformedRecord
+		}
+		return j, nil
+	}
+}
@@ -0,0 +1,83 @@
+package scryfall
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const projectionPayload = `[
+  {"id":"a","name":"Alpha","set":"lea","collector_number":"1","keywords":["Flying"],"image_uris":{"small":"x"},"prices":{"usd":"1.00"}},
+  {"card_faces":[{"name":"}{"}],"prices":{"usd":null,"eur":"2.00"},"id":"b","set":"m10","collector_number":"2\"a"}
+]`
+
+type priceRow struct {
+	ID              string     `json:"id"`
+	Set             string     `json:"set"`
+	CollectorNumber string     `json:"collector_number"`
+	Prices          CardPrices `json:"prices"`
+}
+
+func TestProcessBulkStream_CustomType(t *testing.T) {
+	t.Parallel()
+
+	var rows []priceRow
+	err := ProcessBulkStream(context.Background(), strings.NewReader(projectionPayload), func(row priceRow) error {
+		rows = append(rows, row)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []priceRow{
+		{ID: "a", Set: "lea", CollectorNumber: "1", Prices: CardPrices{USD: "1.00"}},
+		{ID: "b", Set: "m10", CollectorNumber: `2"a`, Prices: CardPrices{EUR: "2.00"}},
+	}, rows)
+}
+
+func TestProcessBulkDataStream_WithFields(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient()
+	var cards []Card
+	err := client.ProcessBulkDataStream(strings.NewReader(projectionPayload), func(card Card) error {
+		cards = append(cards, card)
+		return nil
+	}, WithFields("id", "prices"))
+	require.NoError(t, err)
+	require.Len(t, cards, 2)
+	require.Equal(t, Card{ID: "a", Prices: CardPrices{USD: "1.00"}}, cards[0])
+	require.Equal(t, Card{ID: "b", Prices: CardPrices{EUR: "2.00"}}, cards[1])
+}
+
+func TestProcessBulkDataStream_WithFieldsIgnoresCase(t *testing.T) {
+	t.Parallel()
+
+	payload := `[{"ID":"a","Name":"Alpha","SET":"lea"}]`
+	client := NewClient()
+	var cards []Card
+	err := client.ProcessBulkDataStream(strings.NewReader(payload), func(card Card) error {
+		cards = append(cards, card)
+		return nil
+	}, WithFields("id", "Set"))
+	require.NoError(t, err)
+	require.Equal(t, []Card{{ID: "a", Set: "lea"}}, cards)
+}
+
+func TestProjectFields(t *testing.T) {
+	t.Parallel()
+
+	keep := map[string]bool{"id": true, "b\"c": true}
+	out, err := projectFields([]byte(` { "x" : [1,{"id":"nested"}], "id" : "top", "b\"c":true, "n":-1.5e3 } `), keep)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id":"top","b\"c":true}`, string(out))
+
+	out, err = projectFields([]byte(`{}`), keep)
+	require.NoError(t, err)
+	require.Equal(t, `{}`, string(out))
+
+	for _, bad := range []string{`{"id"}`, `{"id":"a"`, `{"id":"a" "x":1}`, `{id:1}`} {
+		_, err := projectFields([]byte(bad), keep)
+		require.ErrorIs(t, err, errMalformedRecord, bad)
+	}
+}
@@ -0,0 +1,145 @@
+package scryfall
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const defaultPriorityAging = 2 * time.Second
+
+// Priority orders requests competing for the client's rate limiter.
+type Priority int
+
+const (
+	// PriorityBackground is intended for bulk refreshes and backfills.
+	PriorityBackground Priority = iota
+	// PriorityNormal is the default priority.
+	PriorityNormal
+	// PriorityInteractive is intended for user-facing lookups.
+	PriorityInteractive
+)
+
+type priorityContextKey struct{}
+
+// ContextWithPriority returns a context whose requests are scheduled at p.
+func ContextWithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityContextKey{}, p)
+}
+
+// PriorityFromContext returns the priority stored in ctx, or PriorityNormal.
+func PriorityFromContext(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityContextKey{}).(Priority); ok {
+		return p
+	}
+	return PriorityNormal
+}
+
+// WithPriorityAging sets how long a queued request waits before its
+// priority is raised by one level, guaranteeing background work progresses
+// under sustained interactive load.
+func WithPriorityAging(interval time.Duration) Option {
+	return func(c *Client) {
+		if interval > 0 {
+			c.priorityAging = interval
+		}
+	}
+}
+
+// scheduler serialises access to a rate limiter so that the highest
+// priority waiter is always the next to take a token.
+type scheduler struct {
+	limiter *rate.Limiter
+	aging   time.Duration
+
+	mu      sync.Mutex
+	busy    bool
+	seq     uint64
+	waiters []*schedWaiter
+}
+
+type schedWaiter struct {
+	priority Priority
+	enqueued time.Time
+	seq      uint64
+	ready    chan struct{}
+}
+
+func newScheduler(limiter *rate.Limiter, aging time.Duration) *scheduler {
+	return &scheduler{limiter: limiter, aging: aging}
+}
+
+// Wait blocks until it is the caller's turn and a limiter token is available.
+func (s *scheduler) Wait(ctx context.Context, p Priority) error {
+	s.mu.Lock()
+	if !s.busy {
+		s.busy = true
+		s.mu.Unlock()
+	} else {
+		s.seq++
+		w := &schedWaiter{priority: p, enqueued: time.Now(), seq: s.seq, ready: make(chan struct{})}
+		s.waiters = append(s.waiters, w)
+		s.mu.Unlock()
+
+		select {
+		case <-w.ready:
+		case <-ctx.Done():
+			s.mu.Lock()
+			if s.remove(w) {
+				s.mu.Unlock()
+				return ctx.Err()
+			}
+			s.mu.Unlock()
+			// The turn was handed over concurrently; pass it on.
+			s.release()
+			return ctx.Err()
+		}
+	}
+	defer s.release()
+	return s.limiter.Wait(ctx)
+}
+
+// release hands the turn to the best queued waiter, if any.
+func (s *scheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.waiters) == 0 {
+		s.busy = false
+		return
+	}
+	now := time.Now()
+	best := 0
+	for i := 1; i < len(s.waiters); i++ {
+		if s.before(s.waiters[i], s.waiters[best], now) {
+			best = i
+		}
+	}
+	w := s.waiters[best]
+	s.waiters = append(s.waiters[:best], s.waiters[best+1:]...)
+	close(w.ready)
+}
+
+// before reports whether a should be served ahead of b.
+func (s *scheduler) before(a, b *schedWaiter, now time.Time) bool {
+	pa, pb := s.effective(a, now), s.effective(b, now)
+	if pa != pb {
+		return pa > pb
+	}
+	return a.seq < b.seq
+}
+
+func (s *scheduler) effective(w *schedWaiter, now time.Time) int64 {
+	return int64(w.priority) + int64(now.Sub(w.enqueued)/s.aging)
+}
+
+func (s *scheduler) remove(w *schedWaiter) bool {
+	for i, queued := range s.waiters {
+		if queued == w {
+			s.waiters = append(s.waiters[:i], s.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package scryfall
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestScheduler_ServesHigherPriorityFirst(t *testing.T) {
+	t.Parallel()
+
+	limiter := rate.NewLimiter(rate.Every(10*time.Millisecond), 1)
+	s := newScheduler(limiter, time.Hour)
+	ctx := context.Background()
+
+	// Hold the turn so every other caller queues.
+	s.mu.Lock()
+	s.busy = true
+	s.mu.Unlock()
+
+	var mu sync.Mutex
+	var order []Priority
+	var wg sync.WaitGroup
+	enqueue := func(p Priority) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, s.Wait(ctx, p))
+			mu.Lock()
+			order = append(order, p)
+			mu.Unlock()
+		}()
+		require.Eventually(t, func() bool {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			for _, w := range s.waiters {
+				if w.priority == p {
+					return true
+				}
+			}
+			return false
+		}, time.Second, time.Millisecond)
+	}
+	enqueue(PriorityBackground)
+	enqueue(PriorityNormal)
+	enqueue(PriorityInteractive)
+
+	s.release()
+	wg.Wait()
+	require.Equal(t, []Priority{PriorityInteractive, PriorityNormal, PriorityBackground}, order)
+}
+
+func TestScheduler_AgingPreventsStarvation(t *testing.T) {
+	t.Parallel()
+
+	s := newScheduler(rate.NewLimiter(rate.Inf, 0), time.Second)
+	now := time.Now()
+	old := &schedWaiter{priority: PriorityBackground, enqueued: now.Add(-3 * time.Second), seq: 1}
+	fresh := &schedWaiter{priority: PriorityInteractive, enqueued: now, seq: 2}
+	require.True(t, s.before(old, fresh, now))
+}
+
+func TestScheduler_CancelledWaiterLeavesQueue(t *testing.T) {
+	t.Parallel()
+
+	s := newScheduler(rate.NewLimiter(rate.Inf, 0), time.Hour)
+	s.mu.Lock()
+	s.busy = true
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() { errc <- s.Wait(ctx, PriorityNormal) }()
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.waiters) == 1
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	require.ErrorIs(t, <-errc, context.Canceled)
+	s.release()
+	require.NoError(t, s.Wait(context.Background(), PriorityNormal))
+}
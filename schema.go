@@ -0,0 +1,353 @@
+package scryfall
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrSchemaDrift is returned by SchemaDriftReport.Err when drift was seen.
+var ErrSchemaDrift = errors.New("scryfall schema drift detected")
+
+// schemaModels are the types whose drift is reported, keyed by type.
+var schemaModels = map[reflect.Type]string{
+	reflect.TypeFor[Card]():         "Card",
+	reflect.TypeFor[CardFace]():     "CardFace",
+	reflect.TypeFor[CardSet]():      "CardSet",
+	reflect.TypeFor[CardBulkData](): "CardBulkData",
+	reflect.TypeFor[Ruling]():       "Ruling",
+}
+
+// ModelDrift counts schema differences for one model. Nested fields that are
+// not models themselves, such as Card.Prices, are reported with dotted paths
+// like "prices.usd".
+type ModelDrift struct {
+	// Records is the number of objects of this model inspected.
+	Records int64 `json:"records"`
+	// UnknownFields counts fields present in the JSON but not in the model.
+	UnknownFields map[string]int64 `json:"unknown_fields,omitempty"`
+	// TypeMismatches counts fields whose JSON type does not fit the model,
+	// keyed by "field: expected X, got Y".
+	TypeMismatches map[string]int64 `json:"type_mismatches,omitempty"`
+	// MissingFields counts model fields absent from the JSON.
+	MissingFields map[string]int64 `json:"missing_fields,omitempty"`
+}
+
+// SchemaDriftReport collects drift per model name.
+type SchemaDriftReport struct {
+	Models map[string]*ModelDrift `json:"models"`
+}
+
+// HasDrift reports whether any unknown field or type mismatch was seen, or
+// whether a model field was missing from every inspected object. Fields that
+// are only sometimes absent, such as Card.Power, are optional in Scryfall's
+// schema and are not treated as drift.
+func (r SchemaDriftReport) HasDrift() bool {
+	return len(r.problems()) > 0
+}
+
+// Err returns nil when no drift was seen, and otherwise an error wrapping
+// ErrSchemaDrift that lists every problem, suitable for failing a CI job.
+func (r SchemaDriftReport) Err() error {
+	problems := r.problems()
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w:\n  %s", ErrSchemaDrift, strings.Join(problems, "\n  "))
+}
+
+func (r SchemaDriftReport) problems() []string {
+	var problems []string
+	for _, name := range slices.Sorted(maps.Keys(r.Models)) {
+		m := r.Models[name]
+		for _, f := range slices.Sorted(maps.Keys(m.UnknownFields)) {
+			problems = append(problems, fmt.Sprintf("%s: unknown field %q (%d)", name, f, m.UnknownFields[f]))
+		}
+		for _, f := range slices.Sorted(maps.Keys(m.TypeMismatches)) {
+			problems = append(problems, fmt.Sprintf("%s: type mismatch %s (%d)", name, f, m.TypeMismatches[f]))
+		}
+		for _, f := range slices.Sorted(maps.Keys(m.MissingFields)) {
+			if m.MissingFields[f] == m.Records {
+				problems = append(problems, fmt.Sprintf("%s: field %q missing from all %d records", name, f, m.Records))
+			}
+		}
+	}
+	return problems
+}
+
+// SchemaOption configures a SchemaDetector.
+type SchemaOption func(*SchemaDetector)
+
+// IgnoreFields stops the named JSON fields of a model, such as "Card", from
+// being reported as unknown. Use it for fields Scryfall sends that the
+// models deliberately leave out.
+func IgnoreFields(model string, fields ...string) SchemaOption {
+	return func(d *SchemaDetector) {
+		if d.ignored[model] == nil {
+			d.ignored[model] = make(map[string]bool)
+		}
+		for _, f := range fields {
+			d.ignored[model][f] = true
+		}
+	}
+}
+
+// SchemaDetector compares JSON payloads against the library's models and
+// accumulates the differences. It is safe for concurrent use.
+type SchemaDetector struct {
+	ignored map[string]map[string]bool
+
+	mu     sync.Mutex
+	report SchemaDriftReport
+}
+
+// NewSchemaDetector constructs an empty detector.
+func NewSchemaDetector(opts ...SchemaOption) *SchemaDetector {
+	d := &SchemaDetector{
+		ignored: make(map[string]map[string]bool),
+		report:  SchemaDriftReport{Models: make(map[string]*ModelDrift)},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(d)
+		}
+	}
+	return d
+}
+
+// WithSchemaDetector checks every API response and bulk stream the client
+// decodes against d.
+func WithSchemaDetector(d *SchemaDetector) Option {
+	return func(c *Client) {
+		c.schema = d
+	}
+}
+
+// WithSchemaCheck checks every bulk record against d before decoding it.
+func WithSchemaCheck(d *SchemaDetector) BulkOption {
+	return func(o *bulkOptions) {
+		o.schema = d
+	}
+}
+
+// Observe compares the JSON document raw with the type of dest, which is
+// typically the value raw is about to be decoded into. Invalid JSON is
+// ignored; the decoder reports it.
+func (d *SchemaDetector) Observe(raw []byte, dest any) {
+	if d == nil || dest == nil {
+		return
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return
+	}
+	found := make(map[string]*ModelDrift)
+	d.walk(found, reflect.TypeOf(dest), doc, "", "")
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for name, f := range found {
+		m := d.report.Models[name]
+		if m == nil {
+			m = &ModelDrift{}
+			d.report.Models[name] = m
+		}
+		m.Records += f.Records
+		m.UnknownFields = addCounts(m.UnknownFields, f.UnknownFields)
+		m.TypeMismatches = addCounts(m.TypeMismatches, f.TypeMismatches)
+		m.MissingFields = addCounts(m.MissingFields, f.MissingFields)
+	}
+}
+
+// Report returns a snapshot of the drift seen so far.
+func (d *SchemaDetector) Report() SchemaDriftReport {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := SchemaDriftReport{Models: make(map[string]*ModelDrift, len(d.report.Models))}
+	for name, m := range d.report.Models {
+		out.Models[name] = &ModelDrift{
+			Records:        m.Records,
+			UnknownFields:  addCounts(nil, m.UnknownFields),
+			TypeMismatches: addCounts(nil, m.TypeMismatches),
+			MissingFields:  addCounts(nil, m.MissingFields),
+		}
+	}
+	return out
+}
+
+func addCounts(dst, src map[string]int64) map[string]int64 {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]int64, len(src))
+	}
+	for k, v := range src {
+		dst[k] += v
+	}
+	return dst
+}
+
+// walk compares v with t. modelName is the nearest enclosing model, if any,
+// and path the dotted field path relative to it.
+func (d *SchemaDetector) walk(found map[string]*ModelDrift, t reflect.Type, v any, modelName, path string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if v == nil || t.Kind() == reflect.Interface {
+		return
+	}
+
+	isModel := false
+	if name, ok := schemaModels[t]; ok {
+		modelName, path, isModel = name, "", true
+		if found[name] == nil {
+			found[name] = &ModelDrift{}
+		}
+	}
+	model := found[modelName]
+
+	mismatch := func(got string) {
+		if model == nil {
+			return
+		}
+		if model.TypeMismatches == nil {
+			model.TypeMismatches = make(map[string]int64)
+		}
+		model.TypeMismatches[fmt.Sprintf("%s: expected %s, got %s", path, t.Kind(), got)]++
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			mismatch(jsonKind(v))
+			return
+		}
+		if isModel {
+			model.Records++
+		}
+		fields := structFields(t)
+		for key, val := range obj {
+			ft, ok := fields[key]
+			if !ok {
+				if model != nil && !d.ignored[modelName][joinPath(path, key)] {
+					if model.UnknownFields == nil {
+						model.UnknownFields = make(map[string]int64)
+					}
+					model.UnknownFields[joinPath(path, key)]++
+				}
+				continue
+			}
+			d.walk(found, ft, val, modelName, joinPath(path, key))
+		}
+		if model != nil {
+			for key := range fields {
+				if _, ok := obj[key]; !ok {
+					if model.MissingFields == nil {
+						model.MissingFields = make(map[string]int64)
+					}
+					model.MissingFields[joinPath(path, key)]++
+				}
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		arr, ok := v.([]any)
+		if !ok {
+			mismatch(jsonKind(v))
+			return
+		}
+		for _, el := range arr {
+			d.walk(found, t.Elem(), el, modelName, path)
+		}
+	case reflect.Map:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			mismatch(jsonKind(v))
+			return
+		}
+		for _, el := range obj {
+			d.walk(found, t.Elem(), el, modelName, path)
+		}
+	case reflect.String:
+		if _, ok := v.(string); !ok {
+			mismatch(jsonKind(v))
+		}
+	case reflect.Bool:
+		if _, ok := v.(bool); !ok {
+			mismatch(jsonKind(v))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := v.(json.Number)
+		if !ok {
+			mismatch(jsonKind(v))
+			return
+		}
+		if _, err := n.Int64(); err != nil {
+			mismatch("non-integer number")
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := v.(json.Number); !ok {
+			mismatch(jsonKind(v))
+		}
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func jsonKind(v any) string {
+	switch v.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case json.Number:
+		return "number"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+var structFieldCache sync.Map // reflect.Type -> map[string]reflect.Type
+
+// structFields maps the JSON names of t's exported fields to their types.
+func structFields(t reflect.Type) map[string]reflect.Type {
+	if cached, ok := structFieldCache.Load(t); ok {
+		return cached.(map[string]reflect.Type)
+	}
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	structFieldCache.Store(t, fields)
+	return fields
+}
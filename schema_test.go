@@ -0,0 +1,101 @@
+package scryfall
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestSchemaDetector_BulkStream(t *testing.T) {
+	t.Parallel()
+
+	payload := `[
+  {"id":"a","object":"card","cmc":"3","prices":{"usd":1.5,"new_market":"x"},"card_faces":[{"name":"F","flavour":"typo"}]},
+  {"id":"b","object":"card","tcgplayer_id":1.5}
+]`
+	d := NewSchemaDetector(IgnoreFields("Card", "object"))
+	var ids []string
+	err := ProcessBulkStream(context.Background(), strings.NewReader(payload), func(card Card) error {
+		ids = append(ids, card.ID)
+		return nil
+	}, WithSchemaCheck(d), WithLenient(0))
+	require.NoError(t, err)
+	require.Empty(t, ids, "both records fail to decode but are still inspected")
+
+	report := d.Report()
+	card := report.Models["Card"]
+	require.NotNil(t, card)
+	require.Equal(t, int64(2), card.Records)
+	require.Equal(t, map[string]int64{"prices.new_market": 1}, card.UnknownFields)
+	require.Equal(t, map[string]int64{
+		"cmc: expected float64, got string":                  1,
+		"prices.usd: expected string, got number":            1,
+		"tcgplayer_id: expected int, got non-integer number": 1,
+	}, card.TypeMismatches)
+	require.Equal(t, int64(2), card.MissingFields["name"])
+	require.Equal(t, int64(1), card.MissingFields["prices.eur"])
+
+	face := report.Models["CardFace"]
+	require.Equal(t, int64(1), face.Records)
+	require.Equal(t, map[string]int64{"flavour": 1}, face.UnknownFields)
+
+	require.True(t, report.HasDrift())
+	err = report.Err()
+	require.ErrorIs(t, err, ErrSchemaDrift)
+	require.ErrorContains(t, err, `Card: unknown field "prices.new_market" (1)`)
+	require.ErrorContains(t, err, `Card: field "name" missing from all 2 records`)
+	require.NotContains(t, err.Error(), `"object"`)
+	require.NotContains(t, err.Error(), `"prices.eur"`)
+}
+
+func TestSchemaDetector_ClientBulkStream(t *testing.T) {
+	t.Parallel()
+
+	d := NewSchemaDetector()
+	client := NewClient(WithSchemaDetector(d))
+	payload := `[{"id":"a","name":"A","surprise":true}]`
+
+	err := client.ProcessBulkDataStream(strings.NewReader(payload), func(Card) error { return nil })
+	require.NoError(t, err)
+	err = client.ProcessBulkDataBatches(context.Background(), strings.NewReader(payload), func([]Card) error { return nil })
+	require.NoError(t, err)
+
+	card := d.Report().Models["Card"]
+	require.NotNil(t, card)
+	require.Equal(t, int64(2), card.Records)
+	require.Equal(t, map[string]int64{"surprise": 2}, card.UnknownFields)
+}
+
+func TestSchemaDetector_APIResponses(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"object":"list","data":[{"id":"s","code":"lea","name":"Alpha","released_at":"1993-08-05","set_type":"core","card_count":295,"digital":false,"nonfoil_only":true,"foil_only":false,"icon_svg_uri":"x","block":"Core"}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	d := NewSchemaDetector()
+	client := NewClient(WithBaseURL(server.URL), WithLimiter(rate.NewLimiter(rate.Inf, 0)), WithSchemaDetector(d))
+	sets, err := client.ListSets(context.Background())
+	require.NoError(t, err)
+	require.Len(t, sets, 1)
+
+	report := d.Report()
+	require.Equal(t, &ModelDrift{Records: 1, UnknownFields: map[string]int64{"block": 1}}, report.Models["CardSet"])
+	require.ErrorContains(t, report.Err(), `CardSet: unknown field "block"`)
+}
+
+func TestSchemaDetector_NoDrift(t *testing.T) {
+	t.Parallel()
+
+	d := NewSchemaDetector()
+	d.Observe([]byte(`{"oracle_id":"o","source":"wotc","published_at":"2020","comment":"c","object":"ruling"}`), &Ruling{})
+	d.Observe([]byte(`not json`), &Ruling{})
+	require.False(t, d.Report().HasDrift())
+	require.NoError(t, d.Report().Err())
+}
@@ -0,0 +1,196 @@
+package scryfall
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Span names emitted by the client.
+const (
+	SpanLimiterWait = "scryfall.limiter_wait"
+	SpanHTTP        = "scryfall.http"
+	SpanDecode      = "scryfall.decode"
+)
+
+// Attribute is a key/value pair attached to a span.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Span is the subset of an OpenTelemetry span used by the client. Adapters
+// over go.opentelemetry.io/otel/trace can implement it directly.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+	// TraceID and SpanID return hex-encoded identifiers used to correlate
+	// log lines with traces. They may be empty.
+	TraceID() string
+	SpanID() string
+}
+
+// Tracer starts spans. Start must return a context carrying the new span so
+// that child spans started from it are parented correctly.
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// WithTracer enables tracing of client operations.
+func WithTracer(tracer Tracer) Option {
+	return func(c *Client) {
+		if tracer != nil {
+			c.tracer = tracer
+		}
+	}
+}
+
+type spanContextKey struct{}
+
+// SpanFromContext returns the client span stored in ctx, if any.
+func SpanFromContext(ctx context.Context) (Span, bool) {
+	span, ok := ctx.Value(spanContextKey{}).(Span)
+	return span, ok
+}
+
+// startSpan starts a span and records it in the returned context so log
+// lines emitted under it can carry its identifiers.
+func (c *Client) startSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	ctx, span := c.tracer.Start(ctx, name, attrs...)
+	return context.WithValue(ctx, spanContextKey{}, span), span
+}
+
+// traceFields appends trace correlation fields for the span in ctx.
+func traceFields(ctx context.Context, keyvals ...any) []any {
+	span, ok := SpanFromContext(ctx)
+	if !ok || span.TraceID() == "" {
+		return keyvals
+	}
+	return append(keyvals, "trace_id", span.TraceID(), "span_id", span.SpanID())
+}
+
+// endSpan records err, if any, and ends span.
+func endSpan(span Span, err error) {
+	if err != nil && !errors.Is(err, errStopIteration) {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, _ string, _ ...Attribute) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attribute) {}
+func (noopSpan) RecordError(error)          {}
+func (noopSpan) End()                       {}
+func (noopSpan) TraceID() string            { return "" }
+func (noopSpan) SpanID() string             { return "" }
+
+// RecordedSpan is a finished span captured by InMemoryTracer.
+type RecordedSpan struct {
+	Name       string
+	TraceID    string
+	SpanID     string
+	ParentID   string
+	Attributes map[string]any
+	Errors     []error
+	Start      time.Time
+	End        time.Time
+}
+
+// InMemoryTracer records finished spans in memory. It is intended for tests
+// and debugging.
+type InMemoryTracer struct {
+	mu    sync.Mutex
+	spans []RecordedSpan
+}
+
+// NewInMemoryTracer constructs an empty InMemoryTracer.
+func NewInMemoryTracer() *InMemoryTracer {
+	return &InMemoryTracer{}
+}
+
+// Start implements Tracer.
+func (t *InMemoryTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	span := &memorySpan{
+		tracer: t,
+		rec: RecordedSpan{
+			Name:       name,
+			SpanID:     randomHex(8),
+			Attributes: make(map[string]any, len(attrs)),
+			Start:      time.Now(),
+		},
+	}
+	if parent, ok := ctx.Value(memorySpanKey{}).(*memorySpan); ok {
+		span.rec.TraceID = parent.rec.TraceID
+		span.rec.ParentID = parent.rec.SpanID
+	} else {
+		span.rec.TraceID = randomHex(16)
+	}
+	span.SetAttributes(attrs...)
+	return context.WithValue(ctx, memorySpanKey{}, span), span
+}
+
+// Spans returns the spans finished so far, in completion order.
+func (t *InMemoryTracer) Spans() []RecordedSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]RecordedSpan(nil), t.spans...)
+}
+
+type memorySpanKey struct{}
+
+type memorySpan struct {
+	tracer *InMemoryTracer
+	mu     sync.Mutex
+	rec    RecordedSpan
+	ended  bool
+}
+
+func (s *memorySpan) SetAttributes(attrs ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, attr := range attrs {
+		s.rec.Attributes[attr.Key] = attr.Value
+	}
+}
+
+func (s *memorySpan) RecordError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rec.Errors = append(s.rec.Errors, err)
+}
+
+func (s *memorySpan) End() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.rec.End = time.Now()
+	rec := s.rec
+	s.mu.Unlock()
+
+	s.tracer.mu.Lock()
+	s.tracer.spans = append(s.tracer.spans, rec)
+	s.tracer.mu.Unlock()
+}
+
+func (s *memorySpan) TraceID() string { return s.rec.TraceID }
+func (s *memorySpan) SpanID() string  { return s.rec.SpanID }
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,72 @@
+package scryfall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestTracing_SpansPerPhase(t *testing.T) {
+	t.Parallel()
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/bulk.json" {
+			require.NoError(t, json.NewEncoder(w).Encode([]Card{{ID: "card-1"}, {ID: "card-2"}}))
+			return
+		}
+		traceparent = r.Header.Get("Traceparent")
+		require.NoError(t, json.NewEncoder(w).Encode(Card{ID: "abc-123"}))
+	}))
+	t.Cleanup(server.Close)
+
+	var logs bytes.Buffer
+	logger := log.NewWithOptions(&logs, log.Options{Level: log.DebugLevel})
+	tracer := NewInMemoryTracer()
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithLimiter(rate.NewLimiter(rate.Inf, 0)),
+		WithLogger(logger),
+		WithTracer(tracer),
+	)
+
+	_, err := client.GetCardByID(context.Background(), "abc-123")
+	require.NoError(t, err)
+
+	spans := map[string]RecordedSpan{}
+	for _, span := range tracer.Spans() {
+		spans[span.Name] = span
+	}
+	root := spans["scryfall."+OpGetCardByID]
+	require.NotEmpty(t, root.TraceID)
+	for _, name := range []string{SpanLimiterWait, SpanHTTP, SpanDecode} {
+		require.Contains(t, spans, name)
+		require.Equal(t, root.TraceID, spans[name].TraceID, name)
+	}
+	require.Equal(t, 200, spans[SpanHTTP].Attributes["http.status_code"])
+	require.Equal(t, "00-"+root.TraceID+"-"+spans[SpanHTTP].SpanID+"-01", traceparent)
+	require.Contains(t, logs.String(), "trace_id="+root.TraceID)
+
+	err = client.DownloadBulkDataStream(context.Background(), server.URL+"/bulk.json", func(Card) error {
+		return nil
+	}, nil)
+	require.NoError(t, err)
+
+	var download RecordedSpan
+	for _, span := range tracer.Spans() {
+		if span.Name == "scryfall."+OpDownloadBulkDataStream {
+			download = span
+		}
+	}
+	require.Equal(t, int64(2), download.Attributes["scryfall.cards"])
+	require.Contains(t, download.Attributes, "scryfall.decode_seconds")
+	require.Contains(t, download.Attributes, "scryfall.callback_seconds")
+}